@@ -18,7 +18,7 @@ package xbits
 
 import "math/bits"
 
-func mulAddVWW(z, x []uint64, y, r uint64) (c uint64) {
+func mulAddVWWGeneric(z, x []uint64, y, r uint64) (c uint64) {
 	c = r
 	// The comment near the top of this file discusses this for loop condition.
 	for i := 0; i < len(z) && i < len(x); i++ {
@@ -27,8 +27,19 @@ func mulAddVWW(z, x []uint64, y, r uint64) (c uint64) {
 	return
 }
 
+func addMulVVW(z, x []uint64, y uint64) (c uint64) {
+	// The comment near the top of this file discusses this for loop condition.
+	for i := 0; i < len(z) && i < len(x); i++ {
+		z1, z0 := mul128(x[i], y, z[i])
+		lo, cc := bits.Add64(z0, c, 0)
+		c, z[i] = cc, lo
+		c += z1
+	}
+	return
+}
+
 // The resulting carry c is either 0 or 1.
-func addVV(z, x, y []uint64) (c uint64) {
+func addVVGeneric(z, x, y []uint64) (c uint64) {
 	// The comment near the top of this file discusses this for loop condition.
 	for i := 0; i < len(z) && i < len(x) && i < len(y); i++ {
 		zi, cc := bits.Add64(x[i], y[i], c)
@@ -39,7 +50,7 @@ func addVV(z, x, y []uint64) (c uint64) {
 }
 
 // The resulting carry c is either 0 or 1.
-func subVV(z, x, y []uint64) (c uint64) {
+func subVVGeneric(z, x, y []uint64) (c uint64) {
 	// The comment near the top of this file discusses this for loop condition.
 	for i := 0; i < len(z) && i < len(x) && i < len(y); i++ {
 		zi, cc := bits.Sub(uint(x[i]), uint(y[i]), uint(c))
@@ -0,0 +1,74 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+package xbits
+
+// hasADX reports whether the CPU supports the BMI2 and ADX
+// instruction set extensions, which mul512ADX and sqr512ADX
+// require.
+var hasADX = func() bool {
+	maxID, _, _, _ := cpuid(0, 0)
+	if maxID < 7 {
+		return false
+	}
+	_, ebx, _, _ := cpuid(7, 0)
+	const (
+		bmi2 = 1 << 8
+		adx  = 1 << 19
+	)
+	return ebx&bmi2 != 0 && ebx&adx != 0
+}()
+
+// cpuid executes the CPUID instruction with the provided
+// leaf and sub-leaf.
+//
+// implemented in arith_amd64.s
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+// mul512ADX sets z[0:8] to the 512-bit product of x*y
+// using MULX, ADCX, and ADOX.
+//
+// implemented in arith_amd64.s
+//
+//go:noescape
+func mul512ADX(z *uint64, x, y *Uint256)
+
+// mul512 sets z to the 512-bit product of x*y.
+//
+// mul512 has the following conditions:
+//
+//	len(z) == 8
+//
+// This function's execution time does not depend on its inputs.
+func mul512(z []uint64, x, y Uint256) {
+	if hasADX {
+		_ = z[7] // bounds check hint
+		mul512ADX(&z[0], &x, &y)
+	} else {
+		mul512Generic(z, x, y)
+	}
+}
+
+// sqr512ADX sets z[0:8] to the 512-bit square of x using
+// MULX, ADCX, and ADOX.
+//
+// implemented in arith_amd64.s
+//
+//go:noescape
+func sqr512ADX(z *uint64, x *Uint256)
+
+// sqr512 sets z to the 512-bit square of x.
+//
+// sqr512 has the following conditions:
+//
+//	len(z) == 8
+//
+// This function's execution time does not depend on its inputs.
+func sqr512(z []uint64, x Uint256) {
+	if hasADX {
+		_ = z[7] // bounds check hint
+		sqr512ADX(&z[0], &x)
+	} else {
+		sqr512Generic(z, x)
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+#include "textflag.h"
+
+// func mulAddVWW(z, x []uint64, y, r uint64) (c uint64)
+TEXT ·mulAddVWW(SB), NOSPLIT, $0-72
+	MOVQ z_base+0(FP), R10
+	MOVQ z_len+8(FP), R11
+	MOVQ x_base+24(FP), R8
+	MOVQ y+48(FP), R9
+	MOVQ r+56(FP), CX // c = r
+	MOVQ $0, BX       // i = 0
+	JMP  E5
+
+L5:
+	MOVQ (R8)(BX*8), AX
+	MULQ R9
+	ADDQ CX, AX
+	ADCQ $0, DX
+	MOVQ AX, (R10)(BX*8)
+	MOVQ DX, CX
+	ADDQ $1, BX         // i++
+
+E5:
+	CMPQ BX, R11 // i < n
+	JL   L5
+
+	MOVQ CX, c+64(FP)
+	RET
+
+// func addVV(z, x, y []uint64) (c uint64)
+TEXT ·addVV(SB), NOSPLIT, $0-80
+	MOVQ z_len+8(FP), DI
+	MOVQ x_base+24(FP), R8
+	MOVQ y_base+48(FP), R9
+	MOVQ z_base+0(FP), R10
+
+	MOVQ $0, CX // c = 0
+	MOVQ $0, SI // i = 0
+
+	SUBQ $4, DI // n -= 4
+	JL   V1     // if n < 0 goto V1
+
+U1: // n >= 0
+	// regular loop body unrolled 4x
+	ADDQ CX, CX // restore CF
+	MOVQ 0(R8)(SI*8), R11
+	MOVQ 8(R8)(SI*8), R12
+	MOVQ 16(R8)(SI*8), R13
+	MOVQ 24(R8)(SI*8), R14
+	ADCQ 0(R9)(SI*8), R11
+	ADCQ 8(R9)(SI*8), R12
+	ADCQ 16(R9)(SI*8), R13
+	ADCQ 24(R9)(SI*8), R14
+	MOVQ R11, 0(R10)(SI*8)
+	MOVQ R12, 8(R10)(SI*8)
+	MOVQ R13, 16(R10)(SI*8)
+	MOVQ R14, 24(R10)(SI*8)
+	SBBQ CX, CX // save CF
+
+	ADDQ $4, SI // i += 4
+	SUBQ $4, DI // n -= 4
+	JGE  U1     // if n >= 0 goto U1
+
+V1:
+	ADDQ $4, DI // n += 4
+	JLE  E1     // if n <= 0 goto E1
+
+L1: // n > 0
+	ADDQ CX, CX // restore CF
+	MOVQ 0(R8)(SI*8), R11
+	ADCQ 0(R9)(SI*8), R11
+	MOVQ R11, 0(R10)(SI*8)
+	SBBQ CX, CX // save CF
+
+	ADDQ $1, SI // i++
+	SUBQ $1, DI // n--
+	JG   L1     // if n > 0 goto L1
+
+E1:
+	NEGQ CX
+	MOVQ CX, c+72(FP) // return c
+	RET
+
+// func subVV(z, x, y []uint64) (c uint64)
+// (same as addVV except for SBBQ instead of ADCQ and label names)
+TEXT ·subVV(SB), NOSPLIT, $0-80
+	MOVQ z_len+8(FP), DI
+	MOVQ x_base+24(FP), R8
+	MOVQ y_base+48(FP), R9
+	MOVQ z_base+0(FP), R10
+
+	MOVQ $0, CX // c = 0
+	MOVQ $0, SI // i = 0
+
+	SUBQ $4, DI // n -= 4
+	JL   V2     // if n < 0 goto V2
+
+U2: // n >= 0
+	// regular loop body unrolled 4x
+	ADDQ CX, CX // restore CF
+	MOVQ 0(R8)(SI*8), R11
+	MOVQ 8(R8)(SI*8), R12
+	MOVQ 16(R8)(SI*8), R13
+	MOVQ 24(R8)(SI*8), R14
+	SBBQ 0(R9)(SI*8), R11
+	SBBQ 8(R9)(SI*8), R12
+	SBBQ 16(R9)(SI*8), R13
+	SBBQ 24(R9)(SI*8), R14
+	MOVQ R11, 0(R10)(SI*8)
+	MOVQ R12, 8(R10)(SI*8)
+	MOVQ R13, 16(R10)(SI*8)
+	MOVQ R14, 24(R10)(SI*8)
+	SBBQ CX, CX // save CF
+
+	ADDQ $4, SI // i += 4
+	SUBQ $4, DI // n -= 4
+	JGE  U2     // if n >= 0 goto U2
+
+V2:
+	ADDQ $4, DI // n += 4
+	JLE  E2     // if n <= 0 goto E2
+
+L2: // n > 0
+	ADDQ CX, CX // restore CF
+	MOVQ 0(R8)(SI*8), R11
+	SBBQ 0(R9)(SI*8), R11
+	MOVQ R11, 0(R10)(SI*8)
+	SBBQ CX, CX // save CF
+
+	ADDQ $1, SI // i++
+	SUBQ $1, DI // n--
+	JG   L2     // if n > 0 goto L2
+
+E2:
+	NEGQ CX
+	MOVQ CX, c+72(FP) // return c
+	RET
+
+// func mul512ADX(z *uint64, x, y *Uint256)
+//
+// Computes z = x*y one row y_i*x at a time. The five-word
+// accumulator rotates through BX, R8, R9, R10, and R11 so
+// that the lowest word of each row can be stored as soon as
+// it is final. Each row after the first adds the low halves
+// of the partial products on the CF chain (ADCX) and the
+// high halves on the OF chain (ADOX).
+//
+// Clobbers AX, BX, CX, DX, SI, DI, R8-R13.
+TEXT ·mul512ADX(SB), NOSPLIT, $0-24
+	MOVQ z+0(FP), DI
+	MOVQ x+8(FP), SI
+	MOVQ y+16(FP), CX
+	XORQ AX, AX
+
+	// y_0 * x
+	MOVQ  0(CX), DX
+	MULXQ 0(SI), BX, R8
+	MULXQ 8(SI), R12, R9
+	ADDQ  R12, R8
+	MULXQ 16(SI), R12, R10
+	ADCQ  R12, R9
+	MULXQ 24(SI), R12, R11
+	ADCQ  R12, R10
+	ADCQ  AX, R11
+	MOVQ  BX, 0(DI)
+
+	// y_1 * x
+	MOVQ  8(CX), DX
+	XORQ  BX, BX // clears CF and OF
+	MULXQ 0(SI), R12, R13
+	ADCXQ R12, R8
+	ADOXQ R13, R9
+	MULXQ 8(SI), R12, R13
+	ADCXQ R12, R9
+	ADOXQ R13, R10
+	MULXQ 16(SI), R12, R13
+	ADCXQ R12, R10
+	ADOXQ R13, R11
+	MULXQ 24(SI), R12, R13
+	ADCXQ R12, R11
+	ADOXQ R13, BX
+	ADCXQ AX, BX
+	MOVQ  R8, 8(DI)
+
+	// y_2 * x
+	MOVQ  16(CX), DX
+	XORQ  R8, R8 // clears CF and OF
+	MULXQ 0(SI), R12, R13
+	ADCXQ R12, R9
+	ADOXQ R13, R10
+	MULXQ 8(SI), R12, R13
+	ADCXQ R12, R10
+	ADOXQ R13, R11
+	MULXQ 16(SI), R12, R13
+	ADCXQ R12, R11
+	ADOXQ R13, BX
+	MULXQ 24(SI), R12, R13
+	ADCXQ R12, BX
+	ADOXQ R13, R8
+	ADCXQ AX, R8
+	MOVQ  R9, 16(DI)
+
+	// y_3 * x
+	MOVQ  24(CX), DX
+	XORQ  R9, R9 // clears CF and OF
+	MULXQ 0(SI), R12, R13
+	ADCXQ R12, R10
+	ADOXQ R13, R11
+	MULXQ 8(SI), R12, R13
+	ADCXQ R12, R11
+	ADOXQ R13, BX
+	MULXQ 16(SI), R12, R13
+	ADCXQ R12, BX
+	ADOXQ R13, R8
+	MULXQ 24(SI), R12, R13
+	ADCXQ R12, R8
+	ADOXQ R13, R9
+	ADCXQ AX, R9
+	MOVQ  R10, 24(DI)
+	MOVQ  R11, 32(DI)
+	MOVQ  BX, 40(DI)
+	MOVQ  R8, 48(DI)
+	MOVQ  R9, 56(DI)
+	RET
+
+// func sqr512ADX(z *uint64, x *Uint256)
+//
+// Computes z = x^2. The six off-diagonal products x_i*x_j
+// with i < j are summed into R8-R13 (the ADCX and ADOX
+// chains handle the overlapping second row), the sum is
+// doubled into R8-R14, and the four squares x_i^2 are added
+// along the diagonal.
+//
+// Clobbers AX, BX, CX, DX, SI, DI, R8-R14.
+TEXT ·sqr512ADX(SB), NOSPLIT, $0-16
+	MOVQ z+0(FP), DI
+	MOVQ x+8(FP), SI
+
+	// x_0 * (x_1, x_2, x_3)
+	MOVQ  0(SI), DX
+	MULXQ 8(SI), R8, R9
+	MULXQ 16(SI), AX, R10
+	ADDQ  AX, R9
+	MULXQ 24(SI), AX, R11
+	ADCQ  AX, R10
+	ADCQ  $0, R11
+
+	// x_1 * (x_2, x_3)
+	MOVQ  8(SI), DX
+	XORQ  R12, R12
+	XORQ  R13, R13 // clears CF and OF
+	MULXQ 16(SI), AX, CX
+	ADCXQ AX, R10
+	ADOXQ CX, R11
+	MULXQ 24(SI), AX, CX
+	ADCXQ AX, R11
+	ADOXQ CX, R12
+	ADCXQ R13, R12
+
+	// x_2 * x_3
+	MOVQ  16(SI), DX
+	MULXQ 24(SI), AX, R13
+	ADDQ  AX, R12
+	ADCQ  $0, R13
+
+	// Double the off-diagonal sum.
+	XORQ R14, R14
+	ADDQ R8, R8
+	ADCQ R9, R9
+	ADCQ R10, R10
+	ADCQ R11, R11
+	ADCQ R12, R12
+	ADCQ R13, R13
+	ADCQ R14, R14
+
+	// Add x_i^2. MULX does not modify the flags.
+	MOVQ  0(SI), DX
+	MULXQ DX, BX, AX
+	ADDQ  AX, R8
+	MOVQ  8(SI), DX
+	MULXQ DX, AX, CX
+	ADCQ  AX, R9
+	ADCQ  CX, R10
+	MOVQ  16(SI), DX
+	MULXQ DX, AX, CX
+	ADCQ  AX, R11
+	ADCQ  CX, R12
+	MOVQ  24(SI), DX
+	MULXQ DX, AX, CX
+	ADCQ  AX, R13
+	ADCQ  CX, R14
+
+	MOVQ BX, 0(DI)
+	MOVQ R8, 8(DI)
+	MOVQ R9, 16(DI)
+	MOVQ R10, 24(DI)
+	MOVQ R11, 32(DI)
+	MOVQ R12, 40(DI)
+	MOVQ R13, 48(DI)
+	MOVQ R14, 56(DI)
+	RET
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
@@ -0,0 +1,56 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+package xbits
+
+import "testing"
+
+// TestMul512ADX tests mul512ADX directly, since mul512 only
+// uses it when the CPU supports BMI2 and ADX.
+func TestMul512ADX(t *testing.T) {
+	if !hasADX {
+		t.Skip("CPU does not support BMI2 and ADX")
+	}
+	for i := 0; i < 100_000; i++ {
+		w := randWords(8)
+		x := Uint256{w[0], w[1], w[2], w[3]}
+		y := Uint256{w[4], w[5], w[6], w[7]}
+		var z1, z2 [8]uint64
+		mul512ADX(&z1[0], &x, &y)
+		mul512Generic(z2[:], x, y)
+		if z1 != z2 {
+			t.Fatalf("#%d: mul512ADX(%#x, %#x): expected %x, got %x", i, x, y, z2, z1)
+		}
+	}
+}
+
+// TestSqr512ADX tests sqr512ADX directly, since sqr512 only
+// uses it when the CPU supports BMI2 and ADX.
+func TestSqr512ADX(t *testing.T) {
+	if !hasADX {
+		t.Skip("CPU does not support BMI2 and ADX")
+	}
+	for i := 0; i < 100_000; i++ {
+		w := randWords(4)
+		x := Uint256{w[0], w[1], w[2], w[3]}
+		if i == 0 {
+			x = max256
+		}
+		var z1, z2 [8]uint64
+		sqr512ADX(&z1[0], &x)
+		sqr512Generic(z2[:], x)
+		if z1 != z2 {
+			t.Fatalf("#%d: sqr512ADX(%#x): expected %x, got %x", i, x, z2, z1)
+		}
+	}
+}
+
+// TestMul512NoADX tests mul512 and sqr512 with the generic
+// fallback selected.
+func TestMul512NoADX(t *testing.T) {
+	defer func(v bool) { hasADX = v }(hasADX)
+	hasADX = false
+	TestMul512Overwrite(t)
+	TestMul256(t)
+	TestSqr256Full(t)
+}
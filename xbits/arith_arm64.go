@@ -0,0 +1,34 @@
+//go:build arm64 && !purego
+// +build arm64,!purego
+
+package xbits
+
+// mul512Asm sets z[0:8] to the 512-bit product of x*y.
+//
+// implemented in arith_arm64.s
+//
+//go:noescape
+func mul512Asm(z *uint64, x, y *Uint256)
+
+// mul512 sets z to the 512-bit product of x*y.
+//
+// mul512 has the following conditions:
+//
+//	len(z) == 8
+//
+// This function's execution time does not depend on its inputs.
+func mul512(z []uint64, x, y Uint256) {
+	_ = z[7] // bounds check hint
+	mul512Asm(&z[0], &x, &y)
+}
+
+// sqr512 sets z to the 512-bit square of x.
+//
+// sqr512 has the following conditions:
+//
+//	len(z) == 8
+//
+// This function's execution time does not depend on its inputs.
+func sqr512(z []uint64, x Uint256) {
+	sqr512Generic(z, x)
+}
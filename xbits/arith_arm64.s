@@ -0,0 +1,131 @@
+// Copyright 2013 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build arm64 && !purego
+// +build arm64,!purego
+
+#include "textflag.h"
+
+// func mulAddVWW(z, x []uint64, y, r uint64) (c uint64)
+TEXT ·mulAddVWW(SB), NOSPLIT, $0-72
+	MOVD z_base+0(FP), R1
+	MOVD z_len+8(FP), R0
+	MOVD x_base+24(FP), R2
+	MOVD y+48(FP), R3
+	MOVD r+56(FP), R4 // c = r
+	CBZ  R0, done
+
+loop:
+	MOVD.P 8(R2), R5
+	MUL    R3, R5, R6
+	UMULH  R3, R5, R7
+	ADDS   R4, R6
+	ADC    ZR, R7, R4
+	MOVD.P R6, 8(R1)
+	SUB    $1, R0
+	CBNZ   R0, loop
+
+done:
+	MOVD R4, c+64(FP)
+	RET
+
+// func addVV(z, x, y []uint64) (c uint64)
+TEXT ·addVV(SB), NOSPLIT, $0-80
+	MOVD z_base+0(FP), R1
+	MOVD z_len+8(FP), R0
+	MOVD x_base+24(FP), R2
+	MOVD y_base+48(FP), R3
+	ADDS $0, R0 // clear carry flag
+	CBZ  R0, done
+
+loop:
+	MOVD.P 8(R2), R4
+	MOVD.P 8(R3), R5
+	ADCS   R5, R4
+	MOVD.P R4, 8(R1)
+	SUB    $1, R0
+	CBNZ   R0, loop
+
+done:
+	CSET HS, R0 // extract carry flag
+	MOVD R0, c+72(FP)
+	RET
+
+// func subVV(z, x, y []uint64) (c uint64)
+TEXT ·subVV(SB), NOSPLIT, $0-80
+	MOVD z_base+0(FP), R1
+	MOVD z_len+8(FP), R0
+	MOVD x_base+24(FP), R2
+	MOVD y_base+48(FP), R3
+	CMP  ZR, ZR // set carry flag
+	CBZ  R0, done
+
+loop:
+	MOVD.P 8(R2), R4
+	MOVD.P 8(R3), R5
+	SBCS   R5, R4
+	MOVD.P R4, 8(R1)
+	SUB    $1, R0
+	CBNZ   R0, loop
+
+done:
+	CSET LO, R0 // extract carry flag
+	MOVD R0, c+72(FP)
+	RET
+
+// MULROW computes the five-word product y*x, where x is in
+// R0-R3, into p0-p4. It clobbers R24.
+#define MULROW(y, p0, p1, p2, p3, p4) \
+	MUL   y, R0, p0  \
+	UMULH y, R0, p1  \
+	MUL   y, R1, R24 \
+	UMULH y, R1, p2  \
+	ADDS  R24, p1    \
+	MUL   y, R2, R24 \
+	UMULH y, R2, p3  \
+	ADCS  R24, p2    \
+	MUL   y, R3, R24 \
+	UMULH y, R3, p4  \
+	ADCS  R24, p3    \
+	ADC   ZR, p4
+
+// ADDROW adds the five-word row in R19-R23 to t0-t3 and
+// sets t4 to the carry out.
+#define ADDROW(t0, t1, t2, t3, t4) \
+	ADDS R19, t0 \
+	ADCS R20, t1 \
+	ADCS R21, t2 \
+	ADCS R22, t3 \
+	ADC  ZR, R23, t4
+
+// func mul512Asm(z *uint64, x, y *Uint256)
+TEXT ·mul512Asm(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R25
+	LDP  0(R25), (R0, R1)
+	LDP  16(R25), (R2, R3)
+	MOVD y+16(FP), R25
+	LDP  0(R25), (R4, R5)
+	LDP  16(R25), (R6, R7)
+	MOVD z+0(FP), R25
+
+	// y_0 * x
+	MULROW(R4, R8, R9, R10, R11, R12)
+
+	// y_1 * x
+	MULROW(R5, R19, R20, R21, R22, R23)
+	ADDROW(R9, R10, R11, R12, R13)
+
+	// y_2 * x
+	MULROW(R6, R19, R20, R21, R22, R23)
+	ADDROW(R10, R11, R12, R13, R14)
+
+	// y_3 * x
+	MULROW(R7, R19, R20, R21, R22, R23)
+	ADDROW(R11, R12, R13, R14, R15)
+
+	STP (R8, R9), 0(R25)
+	STP (R10, R11), 16(R25)
+	STP (R12, R13), 32(R25)
+	STP (R14, R15), 48(R25)
+	RET
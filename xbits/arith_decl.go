@@ -0,0 +1,20 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build (amd64 || arm64) && !purego
+// +build amd64 arm64
+// +build !purego
+
+package xbits
+
+// implemented in arith_$GOARCH.s
+
+//go:noescape
+func mulAddVWW(z, x []uint64, y, r uint64) (c uint64)
+
+//go:noescape
+func addVV(z, x, y []uint64) (c uint64)
+
+//go:noescape
+func subVV(z, x, y []uint64) (c uint64)
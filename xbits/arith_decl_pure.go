@@ -0,0 +1,42 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !(amd64 || arm64) || purego
+// +build !amd64,!arm64 purego
+
+package xbits
+
+func mulAddVWW(z, x []uint64, y, r uint64) (c uint64) {
+	return mulAddVWWGeneric(z, x, y, r)
+}
+
+func addVV(z, x, y []uint64) (c uint64) {
+	return addVVGeneric(z, x, y)
+}
+
+func subVV(z, x, y []uint64) (c uint64) {
+	return subVVGeneric(z, x, y)
+}
+
+// mul512 sets z to the 512-bit product of x*y.
+//
+// mul512 has the following conditions:
+//
+//	len(z) == 8
+//
+// This function's execution time does not depend on its inputs.
+func mul512(z []uint64, x, y Uint256) {
+	mul512Generic(z, x, y)
+}
+
+// sqr512 sets z to the 512-bit square of x.
+//
+// sqr512 has the following conditions:
+//
+//	len(z) == 8
+//
+// This function's execution time does not depend on its inputs.
+func sqr512(z []uint64, x Uint256) {
+	sqr512Generic(z, x)
+}
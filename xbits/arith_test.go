@@ -0,0 +1,184 @@
+package xbits
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// randWords returns n random words, biased toward all zero
+// and all one bits to exercise carries.
+func randWords(n int) []uint64 {
+	z := make([]uint64, n)
+	for i := range z {
+		switch rand.Intn(4) {
+		case 0:
+			z[i] = 0
+		case 1:
+			z[i] = ^uint64(0)
+		default:
+			z[i] = rand.Uint64()
+		}
+	}
+	return z
+}
+
+func equalWords(x, y []uint64) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TestArithVV cross-checks the (possibly assembly) vector
+// kernels against their generic versions.
+func TestArithVV(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		fn, ref func(z, x, y []uint64) uint64
+	}{
+		{"addVV", addVV, addVVGeneric},
+		{"subVV", subVV, subVVGeneric},
+	} {
+		for i := 0; i < 10_000; i++ {
+			n := rand.Intn(20)
+			x, y := randWords(n), randWords(n)
+			z1, z2 := make([]uint64, n), make([]uint64, n)
+			c1 := tc.fn(z1, x, y)
+			c2 := tc.ref(z2, x, y)
+			if c1 != c2 || !equalWords(z1, z2) {
+				t.Fatalf("#%d: %s(%x, %x): expected (%x, %d), got (%x, %d)",
+					i, tc.name, x, y, z2, c2, z1, c1)
+			}
+
+			// In place.
+			c1 = tc.fn(x, x, y)
+			if c1 != c2 || !equalWords(x, z2) {
+				t.Fatalf("#%d: %s: in place: expected (%x, %d), got (%x, %d)",
+					i, tc.name, z2, c2, x, c1)
+			}
+		}
+	}
+}
+
+func TestMulAddVWW(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		n := rand.Intn(20)
+		x := randWords(n)
+		yr := randWords(2)
+		z1, z2 := make([]uint64, n), make([]uint64, n)
+		c1 := mulAddVWW(z1, x, yr[0], yr[1])
+		c2 := mulAddVWWGeneric(z2, x, yr[0], yr[1])
+		if c1 != c2 || !equalWords(z1, z2) {
+			t.Fatalf("#%d: mulAddVWW(%x, %#x, %#x): expected (%x, %#x), got (%x, %#x)",
+				i, x, yr[0], yr[1], z2, c2, z1, c1)
+		}
+	}
+}
+
+// TestMul512 cross-checks mul512 against mul512Generic.
+func TestMul512(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		w := randWords(8)
+		x := Uint256{w[0], w[1], w[2], w[3]}
+		y := Uint256{w[4], w[5], w[6], w[7]}
+		var z1, z2 [8]uint64
+		mul512(z1[:], x, y)
+		mul512Generic(z2[:], x, y)
+		if z1 != z2 {
+			t.Fatalf("#%d: mul512(%#x, %#x): expected %x, got %x", i, x, y, z2, z1)
+		}
+	}
+}
+
+// TestMul512Overwrite tests that mul512 ignores the prior
+// contents of z.
+func TestMul512Overwrite(t *testing.T) {
+	x, y := max256, max256
+	var want [8]uint64
+	mul512Generic(want[:], x, y)
+	for _, fn := range []func(z []uint64, x, y Uint256){mul512, mul512Generic} {
+		z := [8]uint64{1, 2, 3, 4, 5, 6, 7, 8}
+		fn(z[:], x, y)
+		if z != want {
+			t.Fatalf("expected %x, got %x", want, z)
+		}
+	}
+}
+
+// TestSqr512 cross-checks sqr512 and sqr512Generic against
+// mul512Generic.
+func TestSqr512(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		w := randWords(4)
+		x := Uint256{w[0], w[1], w[2], w[3]}
+		switch i {
+		case 0:
+			x = Uint256{}
+		case 1:
+			x = max256
+		case 2:
+			x = Uint256{1 << 63, 1 << 63, 1 << 63, 1 << 63}
+		}
+		var want [8]uint64
+		mul512Generic(want[:], x, x)
+		for _, fn := range []func(z []uint64, x Uint256){sqr512, sqr512Generic} {
+			z := [8]uint64{1, 2, 3, 4, 5, 6, 7, 8}
+			fn(z[:], x)
+			if z != want {
+				t.Fatalf("#%d: sqr512(%#x): expected %x, got %x", i, x, want, z)
+			}
+		}
+		if got := sqr256(x); got != (Uint256{want[0], want[1], want[2], want[3]}) {
+			t.Fatalf("#%d: sqr256(%#x): expected %x, got %x", i, x, want[:4], got)
+		}
+	}
+}
+
+func BenchmarkMul512(b *testing.B) {
+	x, y := randBits256(b), randBits256(b)
+	var z [8]uint64
+	b.Run("generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			mul512Generic(z[:], x, y)
+		}
+	})
+	b.Run("asm", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			mul512(z[:], x, y)
+		}
+	})
+}
+
+func BenchmarkSqr512(b *testing.B) {
+	x := randBits256(b)
+	var z [8]uint64
+	b.Run("generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sqr512Generic(z[:], x)
+		}
+	})
+	b.Run("asm", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sqr512(z[:], x)
+		}
+	})
+}
+
+func BenchmarkAddVV(b *testing.B) {
+	x, y, z := randWords(8), randWords(8), make([]uint64, 8)
+	b.Run("generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			addVVGeneric(z, x, y)
+		}
+	})
+	b.Run("asm", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			addVV(z, x, y)
+		}
+	})
+}
@@ -0,0 +1,250 @@
+package xbits
+
+import (
+	"math/bits"
+
+	"github.com/elagergren/ctb/xbits/ct"
+)
+
+// Barrett implements arithmetic modulo any non-zero Uint256
+// using Barrett reduction.
+//
+// Unlike Montgomery, the modulus does not need to be odd
+// and values are not converted to a special domain, so
+// Barrett can be used as a drop-in replacement for
+// Uint256.MulMod.
+//
+// A Barrett is safe for concurrent use.
+type Barrett struct {
+	m Uint256
+	// s is the number of leading zeros in m.
+	s uint
+	// mn is m<<s, so the high bit of mn is set.
+	mn Uint256
+	// mu is ⌊2^512/mn⌋.
+	mu [5]uint64
+	// one is 1 mod m.
+	one Uint256
+}
+
+// NewBarrett creates a Barrett context for the modulus m.
+//
+// NewBarrett panics if m == 0.
+//
+// This function's execution time depends on m.
+func NewBarrett(m Uint256) *Barrett {
+	z := new(Barrett)
+	z.init(m)
+	return z
+}
+
+// init initializes z for the modulus m.
+func (z *Barrett) init(m Uint256) {
+	if m.BitLen() == 0 {
+		panic("division by zero")
+	}
+	z.m = m
+	z.s = uint(m.LeadingZeros())
+	z.mn = m.Lsh(z.s)
+
+	// mu = ⌊2^512/mn⌋. Since mn >= 2^255, mu <= 2^257.
+	u := [10]uint64{8: 1}
+	v := z.mn.words()
+	var q [10]uint64
+	div512(q[:], u[:], v[:])
+	copy(z.mu[:], q[:])
+
+	z.one = z.Reduce(U256(1))
+}
+
+// Modulus returns the modulus m.
+func (z *Barrett) Modulus() Uint256 {
+	return z.m
+}
+
+// Reduce returns x mod m.
+//
+// This function's execution time does not depend on x.
+func (z *Barrett) Reduce(x Uint256) Uint256 {
+	w := [8]uint64{x.u0, x.u1, x.u2, x.u3}
+	return z.reduce(&w)
+}
+
+// Reduce512 returns x mod m.
+//
+// This function's execution time does not depend on x.
+func (z *Barrett) Reduce512(x Uint512) Uint256 {
+	w := x.words()
+	return z.reduce512(&w)
+}
+
+// MulMod returns x*y mod m.
+//
+// x and y can be any Uint256; the result is the same as
+// x.MulMod(y, m).
+//
+// This function's execution time does not depend on x or
+// y.
+func (z *Barrett) MulMod(x, y Uint256) Uint256 {
+	var w [8]uint64
+	mul512(w[:], x, y)
+	return z.reduce512(&w)
+}
+
+// SqrMod returns x^2 mod m.
+//
+// This function's execution time does not depend on x.
+func (z *Barrett) SqrMod(x Uint256) Uint256 {
+	var w [8]uint64
+	sqr512(w[:], x)
+	return z.reduce512(&w)
+}
+
+// ExpMod returns x^y mod m.
+//
+// This function's execution time does not depend on x or
+// y.
+func (z *Barrett) ExpMod(x, y Uint256) Uint256 {
+	// Fixed 4-bit window.
+	var table [16]Uint256
+	table[0] = z.one
+	table[1] = z.Reduce(x)
+	for i := 2; i < len(table); i++ {
+		table[i] = z.mul(table[i-1], table[1])
+	}
+
+	exp := [4]uint64{y.u0, y.u1, y.u2, y.u3}
+	r := z.one
+	for i := len(exp) - 1; i >= 0; i-- {
+		for j := 64 - 4; j >= 0; j -= 4 {
+			r = z.sqr(r)
+			r = z.sqr(r)
+			r = z.sqr(r)
+			r = z.sqr(r)
+			r = z.mul(r, lookup256(table[:], (exp[i]>>uint(j))&15))
+		}
+	}
+	return r
+}
+
+// mul returns x*y mod m for x, y in [0, m).
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Barrett) mul(x, y Uint256) Uint256 {
+	// x*y < m^2, so x*y<<s does not overflow.
+	var w [8]uint64
+	mul512(w[:], x, y)
+	return z.reduce(&w)
+}
+
+// sqr returns x^2 mod m for x in [0, m).
+//
+// This function's execution time does not depend on x.
+func (z *Barrett) sqr(x Uint256) Uint256 {
+	var w [8]uint64
+	sqr512(w[:], x)
+	return z.reduce(&w)
+}
+
+// reduce512 returns x mod m.
+//
+// This function's execution time does not depend on x.
+func (z *Barrett) reduce512(x *[8]uint64) Uint256 {
+	if z.s == 0 {
+		return z.reduce(x)
+	}
+	// x<<s would overflow, so first reduce the high half
+	// using
+	//
+	//    hi*2^256 + lo = (hi mod m)*2^256 + lo (mod m)
+	//
+	// Afterward x < m*2^256, so x<<s < 2^512.
+	hi := z.Reduce(Uint256{x[4], x[5], x[6], x[7]})
+	x[4], x[5], x[6], x[7] = hi.u0, hi.u1, hi.u2, hi.u3
+	return z.reduce(x)
+}
+
+// reduce returns x mod m for x < 2^(512-s).
+//
+// reduce implements Algorithm 14.42 from the Handbook of
+// Applied Cryptography with b = 2^64 and k = 4, using the
+// normalized modulus mn. Since
+//
+//	x mod m = ((x<<s) mod (m<<s)) >> s
+//
+// the result is exact.
+//
+// This function's execution time does not depend on x.
+func (z *Barrett) reduce(x *[8]uint64) Uint256 {
+	lsh512(x, z.s)
+
+	// q1 = ⌊x / b^(k-1)⌋
+	// q2 = q1 * mu
+	// q3 = ⌊q2 / b^(k+1)⌋
+	var q2 [10]uint64
+	for i := 0; i < 5; i++ {
+		var c uint64
+		for j := 0; j < 5; j++ {
+			c, q2[i+j] = mulAdd128(x[i+3], z.mu[j], q2[i+j], c)
+		}
+		q2[i+5] = c
+	}
+	q3 := q2[5:]
+
+	// r2 = q3 * mn mod b^(k+1)
+	mn := [5]uint64{z.mn.u0, z.mn.u1, z.mn.u2, z.mn.u3}
+	var r2 [5]uint64
+	for i := 0; i < 5; i++ {
+		var c uint64
+		for j := 0; j < 5-i; j++ {
+			c, r2[i+j] = mulAdd128(q3[i], mn[j], r2[i+j], c)
+		}
+	}
+
+	// r = x mod b^(k+1) - r2 mod b^(k+1)
+	//
+	// q3 is at most two less than ⌊x/mn⌋, so r < 3*mn.
+	var r [5]uint64
+	var b uint64
+	for i := range r {
+		r[i], b = bits.Sub64(x[i], r2[i], b)
+	}
+	for k := 0; k < 2; k++ {
+		var t [5]uint64
+		b = 0
+		for i := range t {
+			t[i], b = bits.Sub64(r[i], mn[i], b)
+		}
+		// Keep r - mn if r >= mn.
+		for i := range r {
+			r[i] = ct.Select64(b^1, t[i], r[i])
+		}
+	}
+	v := Uint256{r[0], r[1], r[2], r[3]}
+	if z.s != 0 {
+		v = v.Rsh(z.s)
+	}
+	return v
+}
+
+// lsh512 sets x = x<<s for s < 512, discarding the bits
+// that are shifted out.
+//
+// This function's execution time depends on s.
+func lsh512(x *[8]uint64, s uint) {
+	if s == 0 {
+		return
+	}
+	w, n := int(s/64), s%64
+	for i := len(x) - 1; i >= 0; i-- {
+		var v uint64
+		if j := i - w; j >= 0 {
+			v = x[j] << n
+			if j > 0 && n != 0 {
+				v |= x[j-1] >> (64 - n)
+			}
+		}
+		x[i] = v
+	}
+}
@@ -0,0 +1,212 @@
+package xbits
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// barrettEdges are moduli that exercise the normalization
+// shift and the final corrections.
+var barrettEdges = []Uint256{
+	U256(1),
+	U256(2),
+	U256(3),
+	U256(1 << 63),
+	U256(math.MaxUint64),
+	{0, 1, 0, 0},
+	{0, 0, 0, 1},
+	{0, 0, 0, 1 << 63},
+	{1, 0, 0, 1 << 63},
+	{math.MaxUint64, 0, 0, math.MaxUint64},
+	max256,
+	max256.Rsh(1),
+	max256.Lsh(1),
+	max256.Lsh(100),
+}
+
+// randBarrettMod returns a modulus for the Barrett tests.
+func randBarrettMod(t testing.TB, i int) Uint256 {
+	if i < len(barrettEdges) {
+		return barrettEdges[i]
+	}
+	return randMod256(t)
+}
+
+func TestBarrettConstants(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		m := randBarrettMod(t, i)
+		z := NewBarrett(m)
+		if got := z.Modulus(); got != m {
+			t.Fatalf("#%d: Modulus: expected %d, got %d", i, m, got)
+		}
+
+		var bmn, bmu, want big.Int
+		setInt(&bmn, z.mn)
+		bmu.Lsh(big.NewInt(1), 512)
+		bmu.Quo(&bmu, &bmn)
+		setWords(&want, z.mu[:])
+		if bmu.Cmp(&want) != 0 {
+			t.Fatalf("#%d: mu: expected %s, got %s", i, &bmu, &want)
+		}
+		if z.mn.Bit(255) != 1 || z.mn.Rsh(z.s) != m {
+			t.Fatalf("#%d: invalid normalization: %d", i, z.mn)
+		}
+	}
+}
+
+func TestBarrettReduce(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		m := randBarrettMod(t, i%100)
+		z := NewBarrett(m)
+
+		x := randBits256(t)
+		if got, want := z.Reduce(x), x.Rem(m); got != want {
+			t.Fatalf("#%d: Reduce(%d, %d): expected %d, got %d", i, x, m, want, got)
+		}
+
+		w := randBits256(t).MulFull(randBits256(t))
+		switch i % 7 {
+		case 0:
+			w = max256.MulFull(max256)
+		case 1:
+			w = Uint512{u0: ^uint64(0), u1: ^uint64(0), u2: ^uint64(0), u3: ^uint64(0),
+				u4: ^uint64(0), u5: ^uint64(0), u6: ^uint64(0), u7: ^uint64(0)}
+		case 2:
+			w = m.MulFull(U256(rand.Uint64()))
+		}
+		var bw, bm, want big.Int
+		setInt512(&bw, w)
+		setInt(&bm, m)
+		want.Mod(&bw, &bm)
+		if got := z.Reduce512(w); cmpInt(&want, got) != 0 {
+			t.Fatalf("#%d: Reduce512(%d, %d): expected %s, got %d", i, w, m, &want, got)
+		}
+	}
+}
+
+func TestBarrettMulMod(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		m := randBarrettMod(t, i%100)
+		z := NewBarrett(m)
+
+		x, y := randBits256(t), randBits256(t)
+		switch i % 5 {
+		case 0:
+			x, y = max256, max256
+		case 1:
+			x, y = m.Sub(U256(1)), m.Sub(U256(1))
+		}
+		if got, want := z.MulMod(x, y), x.MulMod(y, m); got != want {
+			t.Fatalf("#%d: MulMod(%d, %d, %d): expected %d, got %d",
+				i, x, y, m, want, got)
+		}
+		if got, want := z.SqrMod(x), x.MulMod(x, m); got != want {
+			t.Fatalf("#%d: SqrMod(%d, %d): expected %d, got %d",
+				i, x, m, want, got)
+		}
+	}
+}
+
+func TestBarrettExpMod(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		m := randBarrettMod(t, i)
+		z := NewBarrett(m)
+
+		x := randBits256(t)
+		y := randBits256(t)
+		if i%10 == 0 {
+			y = Uint256{}
+		}
+		got := z.ExpMod(x, y)
+
+		var bz, bx, by, bm big.Int
+		setInt(&bx, x)
+		setInt(&by, y)
+		setInt(&bm, m)
+		bz.Exp(&bx, &by, &bm)
+		if cmpInt(&bz, got) != 0 {
+			t.Fatalf("#%d: %d^%d mod %d: expected %s, got %d",
+				i, x, y, m, &bz, got)
+		}
+	}
+}
+
+func TestNewBarrettZero(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	NewBarrett(Uint256{})
+}
+
+func TestBarrettAllocs(t *testing.T) {
+	x, y := max256, max256.Rsh(1)
+	for _, m := range barrettEdges {
+		n := testing.AllocsPerRun(10, func() {
+			z := NewBarrett(m)
+			Sink256 = z.MulMod(x, y)
+			Sink256 = z.ExpMod(x, y)
+		})
+		if n != 0 {
+			t.Fatalf("%d: expected 0 allocations, got %.1f", m, n)
+		}
+	}
+}
+
+func BenchmarkBarrettMulMod(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		m    Uint256
+	}{
+		{"normalized", max256.Lsh(1)},
+		{"unnormalized", max256.Lsh(1).Rsh(1)},
+	} {
+		x, y := max256.Sub(U256(12345)), max256.Rsh(7)
+		b.Run(bc.name, func(b *testing.B) {
+			b.Run("Barrett", func(b *testing.B) {
+				z := NewBarrett(bc.m)
+				for i := 0; i < b.N; i++ {
+					Sink256 = z.MulMod(x, y)
+				}
+			})
+			b.Run("MulMod", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					Sink256 = x.MulMod(y, bc.m)
+				}
+			})
+			b.Run("MulModCT", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					Sink256 = x.MulModCT(y, bc.m)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkBarrettSqrMod(b *testing.B) {
+	x := max256.Sub(U256(12345))
+	z := NewBarrett(max256.Lsh(1))
+	b.Run("SqrMod", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Sink256 = z.SqrMod(x)
+		}
+	})
+	b.Run("MulMod", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Sink256 = z.MulMod(x, x)
+		}
+	})
+}
+
+func BenchmarkBarrettExpMod(b *testing.B) {
+	x, y := max256.Rsh(3), max256
+	m := max256.Lsh(1)
+	z := NewBarrett(m)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink256 = z.ExpMod(x, y)
+	}
+}
@@ -0,0 +1,306 @@
+package xbits
+
+import (
+	"math/bits"
+	"unsafe"
+)
+
+// This file contains operations over slices of Uint256.
+//
+// Unless otherwise noted, the destination slice z must have
+// the same length as the source slices, and z may alias
+// the source slices exactly.
+
+// AddVec sets z[i] = x[i] + y[i] for each i.
+//
+// Each sum wraps around on overflow.
+//
+// This function's execution time does not depend on the
+// values of its inputs.
+func AddVec(z, x, y []Uint256) {
+	checkVec("AddVec", len(z), len(x), len(y))
+	for len(z) > 0 {
+		n := vecBlock
+		if len(z) < n {
+			n = len(z)
+		}
+		addVecBlock(z[:n], x[:n], y[:n])
+		z, x, y = z[n:], x[n:], y[n:]
+	}
+}
+
+// SubVec sets z[i] = x[i] - y[i] for each i.
+//
+// Each difference wraps around on underflow.
+//
+// This function's execution time does not depend on the
+// values of its inputs.
+func SubVec(z, x, y []Uint256) {
+	checkVec("SubVec", len(z), len(x), len(y))
+	for len(z) > 0 {
+		n := vecBlock
+		if len(z) < n {
+			n = len(z)
+		}
+		subVecBlock(z[:n], x[:n], y[:n])
+		z, x, y = z[n:], x[n:], y[n:]
+	}
+}
+
+// MulScalarVec sets z[i] = x[i] * y for each i.
+//
+// Each product wraps around on overflow.
+//
+// This function's execution time does not depend on the
+// values of its inputs.
+func MulScalarVec(z, x []Uint256, y Uint256) {
+	checkVec("MulScalarVec", len(z), len(x), len(x))
+	for i := range z {
+		z[i] = x[i].Mul(y)
+	}
+}
+
+// SumVec returns the sum of x as a 320-bit integer
+// carry<<256 + sum.
+//
+// This function's execution time does not depend on the
+// values of its inputs.
+func SumVec(x []Uint256) (sum Uint256, carry uint64) {
+	// Add x into vecBlock running sums, one block at a
+	// time, then add up the running sums.
+	var acc [vecBlock]Uint256
+	used := 0
+	for len(x) > 0 {
+		n := vecBlock
+		if len(x) < n {
+			n = len(x)
+		}
+		carry += addVecBlock(acc[:n], acc[:n], x[:n])
+		x = x[n:]
+		if n > used {
+			used = n
+		}
+	}
+	for _, v := range acc[:used] {
+		var c uint64
+		sum, c = Add256(sum, v, 0)
+		carry += c
+	}
+	return sum, carry
+}
+
+// vecBlock is the number of elements that AddVec, SubVec,
+// and SumVec pass to each addVV or subVV call.
+const vecBlock = 64
+
+// vecWords returns the backing array of x as a flat slice
+// of little-endian words, four per element.
+//
+// Uint256 is four uint64 fields, so []Uint256 has the
+// same memory layout as []uint64.
+//
+// len(x) must be in [1, vecBlock].
+func vecWords(x []Uint256) []uint64 {
+	n := 4 * len(x)
+	return (*[4 * vecBlock]uint64)(unsafe.Pointer(&x[0]))[:n:n]
+}
+
+// addVecBlock sets z[i] = x[i] + y[i] for each i with one
+// call to addVV and returns the number of sums that
+// overflowed.
+//
+// len(z) must be in [1, vecBlock].
+//
+// This function's execution time does not depend on the
+// values of its inputs.
+func addVecBlock(z, x, y []Uint256) (n uint64) {
+	// addVV treats the block as one long integer, so the
+	// carry out of each element is added into the next one:
+	// afterward, z[i] = x[i] + y[i] + c where c is the
+	// carry out of z[i-1].
+	//
+	// The carry out of each element can be recovered from
+	// the top words of the operands and the result, so save
+	// the top words in case z aliases x or y.
+	var xt, yt [vecBlock]uint64
+	for i := range z {
+		xt[i], yt[i] = x[i].u3, y[i].u3
+	}
+	zw := vecWords(z)
+	addVV(zw, vecWords(x[:len(z)]), vecWords(y[:len(z)]))
+
+	var c uint64
+	for i := range z {
+		w := zw[4*i : 4*i+4 : 4*i+4]
+		a, b := xt[i], yt[i]
+		cout := (a&b | (a|b)&^w[3]) >> 63
+
+		// Subtract c. If that borrows, the carry out came
+		// from c alone and x[i] + y[i] did not overflow.
+		w[0], c = bits.Sub64(w[0], c, 0)
+		w[1], c = bits.Sub64(w[1], 0, c)
+		w[2], c = bits.Sub64(w[2], 0, c)
+		w[3], c = bits.Sub64(w[3], 0, c)
+		n += cout - c
+		c = cout
+	}
+	return n
+}
+
+// subVecBlock sets z[i] = x[i] - y[i] for each i with one
+// call to subVV.
+//
+// len(z) must be in [1, vecBlock].
+//
+// This function's execution time does not depend on the
+// values of its inputs.
+func subVecBlock(z, x, y []Uint256) {
+	// See addVecBlock. Here, z[i] = x[i] - y[i] - b where
+	// b is the borrow out of z[i-1].
+	var xt, yt [vecBlock]uint64
+	for i := range z {
+		xt[i], yt[i] = x[i].u3, y[i].u3
+	}
+	zw := vecWords(z)
+	subVV(zw, vecWords(x[:len(z)]), vecWords(y[:len(z)]))
+
+	var b uint64
+	for i := range z {
+		w := zw[4*i : 4*i+4 : 4*i+4]
+		p, q := xt[i], yt[i]
+		bout := (^p&q | ^(p^q)&w[3]) >> 63
+
+		// Add b back.
+		w[0], b = bits.Add64(w[0], b, 0)
+		w[1], b = bits.Add64(w[1], 0, b)
+		w[2], b = bits.Add64(w[2], 0, b)
+		w[3], _ = bits.Add64(w[3], 0, b)
+		b = bout
+	}
+}
+
+// MulModVec sets z[i] = x[i] * y mod m for each i.
+//
+// MulModVec panics if m == 0.
+//
+// If m is odd, MulModVec uses Montgomery multiplication
+// and its execution time does not depend on the values of
+// x, y, or m. Otherwise, it uses Barrett reduction and its
+// execution time does not depend on the values of x or y.
+func MulModVec(z, x []Uint256, y, m Uint256) {
+	checkVec("MulModVec", len(z), len(x), len(x))
+	if m.BitLen() == 0 {
+		panic("division by zero")
+	}
+	if m.u0&1 == 0 {
+		b := NewBarrett(m)
+		for i := range z {
+			z[i] = b.MulMod(x[i], y)
+		}
+		return
+	}
+	mont := NewMontgomery(m)
+	// mul(x, y*R) = x*y (mod m), and the result is fully
+	// reduced since x*y*R < R*m.
+	yr := mont.ToMont(y)
+	for i := range z {
+		z[i] = mont.mul(x[i], yr)
+	}
+}
+
+// ModVec sets z[i] = x[i] mod m for each i.
+//
+// ModVec panics if m == 0.
+//
+// If m is odd, ModVec uses Montgomery multiplication and
+// its execution time does not depend on the values of x
+// or m. Otherwise, it uses Barrett reduction and its
+// execution time does not depend on the values of x.
+func ModVec(z, x []Uint256, m Uint256) {
+	checkVec("ModVec", len(z), len(x), len(x))
+	if m.BitLen() == 0 {
+		panic("division by zero")
+	}
+	if m.u0&1 == 0 {
+		b := NewBarrett(m)
+		for i := range z {
+			z[i] = b.Reduce(x[i])
+		}
+		return
+	}
+	mont := NewMontgomery(m)
+	for i := range z {
+		// mul(x, R) = x (mod m).
+		z[i] = mont.mul(x[i], mont.one)
+	}
+}
+
+// ModInverseVec sets z[i] to the multiplicative inverse of
+// x[i] in the ring ℤ/mℤ for each i and reports whether
+// every x[i] is invertible.
+//
+// If any x[i] is not invertible, ModInverseVec returns
+// false and leaves z unchanged.
+//
+// ModInverseVec uses Montgomery's trick, which replaces
+// len(x) inversions with a single inversion and about
+// 3*len(x) multiplications. It panics if m == 0.
+func ModInverseVec(z, x []Uint256, m Uint256) bool {
+	checkVec("ModInverseVec", len(z), len(x), len(x))
+	if m.BitLen() == 0 {
+		panic("division by zero")
+	}
+	if len(x) == 0 {
+		return true
+	}
+
+	var (
+		mul  func(x, y Uint256) Uint256
+		to   func(x Uint256) Uint256
+		from func(x Uint256) Uint256
+	)
+	if m.u0&1 == 1 {
+		mont := NewMontgomery(m)
+		mul, to, from = mont.mul, mont.ToMont, mont.FromMont
+	} else {
+		mul = func(x, y Uint256) Uint256 {
+			return x.MulMod(y, m)
+		}
+		to = func(x Uint256) Uint256 {
+			return x.Rem(m)
+		}
+		from = func(x Uint256) Uint256 {
+			return x
+		}
+	}
+
+	// prefix[i] = x[0] * x[1] * ... * x[i]
+	prefix := make([]Uint256, len(x))
+	prefix[0] = to(x[0])
+	for i := 1; i < len(x); i++ {
+		prefix[i] = mul(prefix[i-1], to(x[i]))
+	}
+
+	inv, ok := modInverse(from(prefix[len(x)-1]), m)
+	if !ok {
+		return false
+	}
+	// inv = (x[0] * x[1] * ... * x[i])^-1
+	inv = to(inv)
+	for i := len(x) - 1; i > 0; i-- {
+		// Read x[i] before writing z[i] in case they alias.
+		xi := to(x[i])
+		z[i] = from(mul(inv, prefix[i-1]))
+		inv = mul(inv, xi)
+	}
+	z[0] = from(inv)
+	return true
+}
+
+// checkVec panics if the slice lengths passed to the
+// function fn do not match.
+func checkVec(fn string, z, x, y int) {
+	if z != x || z != y {
+		panic(fn + ": length mismatch")
+	}
+}
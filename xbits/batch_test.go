@@ -0,0 +1,349 @@
+package xbits
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func randVec256(t testing.TB, n int) []Uint256 {
+	v := make([]Uint256, n)
+	for i := range v {
+		v[i] = randBits256(t)
+	}
+	return v
+}
+
+// randMod256 returns a random non-zero modulus that is odd
+// half of the time.
+func randMod256(t testing.TB) Uint256 {
+	m := randBits256(t)
+	if rand.Intn(2) == 0 {
+		m = m.Or(U256(1))
+	} else {
+		m = m.Lsh(1)
+	}
+	if m.BitLen() == 0 {
+		m = U256(2)
+	}
+	return m
+}
+
+func TestVec256(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		n := rand.Intn(20)
+		x, y := randVec256(t, n), randVec256(t, n)
+		s := randBits256(t)
+		m := randMod256(t)
+
+		for _, tc := range []struct {
+			name string
+			vec  func(z []Uint256)
+			elem func(i int) Uint256
+		}{
+			{"AddVec", func(z []Uint256) { AddVec(z, x, y) }, func(i int) Uint256 { return x[i].Add(y[i]) }},
+			{"SubVec", func(z []Uint256) { SubVec(z, x, y) }, func(i int) Uint256 { return x[i].Sub(y[i]) }},
+			{"MulScalarVec", func(z []Uint256) { MulScalarVec(z, x, s) }, func(i int) Uint256 { return x[i].Mul(s) }},
+			{"MulModVec", func(z []Uint256) { MulModVec(z, x, s, m) }, func(i int) Uint256 { return x[i].MulMod(s, m) }},
+			{"ModVec", func(z []Uint256) { ModVec(z, x, m) }, func(i int) Uint256 { return x[i].Rem(m) }},
+		} {
+			z := make([]Uint256, n)
+			tc.vec(z)
+			for j := range z {
+				if want := tc.elem(j); z[j] != want {
+					t.Fatalf("#%d: %s: [%d]: expected %d, got %d", i, tc.name, j, want, z[j])
+				}
+			}
+		}
+
+		var bs, bx big.Int
+		for _, v := range x {
+			bs.Add(&bs, v.ToBig(&bx))
+		}
+		sum, carry := SumVec(x)
+		want := new(big.Int).Lsh(new(big.Int).SetUint64(carry), 256)
+		if want.Add(want, sum.ToBig(&bx)); want.Cmp(&bs) != 0 {
+			t.Fatalf("#%d: SumVec: expected %s, got %s", i, &bs, want)
+		}
+	}
+}
+
+func TestVec256Alias(t *testing.T) {
+	x, y := randVec256(t, 10), randVec256(t, 10)
+	want := make([]Uint256, len(x))
+	AddVec(want, x, y)
+	AddVec(x, x, y)
+	for i := range x {
+		if x[i] != want[i] {
+			t.Fatalf("[%d]: expected %d, got %d", i, want[i], x[i])
+		}
+	}
+}
+
+func TestSumVecCarry(t *testing.T) {
+	x := make([]Uint256, 1000)
+	for i := range x {
+		x[i] = max256
+	}
+	// 1000*(2^256-1) = 999*2^256 + (2^256-1000)
+	sum, carry := SumVec(x)
+	if carry != 999 || sum != max256.Sub(U256(999)) {
+		t.Fatalf("expected (%d, 999), got (%d, %d)", max256.Sub(U256(999)), sum, carry)
+	}
+}
+
+// TestVec256Carries tests AddVec, SubVec, and SumVec with
+// values that make carries and borrows ripple across
+// element boundaries.
+func TestVec256Carries(t *testing.T) {
+	edge := []Uint256{
+		{},
+		U256(1),
+		U256(2),
+		max256,
+		max256.Sub(U256(1)),
+		U256(1).Lsh(255),
+		U256(1).Lsh(255).Sub(U256(1)),
+	}
+	pick := func(n int) []Uint256 {
+		v := make([]Uint256, n)
+		for i := range v {
+			if rand.Intn(4) == 0 {
+				v[i] = randBits256(t)
+			} else {
+				v[i] = edge[rand.Intn(len(edge))]
+			}
+		}
+		return v
+	}
+	for i := 0; i < 1_000; i++ {
+		n := rand.Intn(3 * vecBlock)
+		x, y := pick(n), pick(n)
+
+		z := make([]Uint256, n)
+		AddVec(z, x, y)
+		for j := range z {
+			if want := x[j].Add(y[j]); z[j] != want {
+				t.Fatalf("#%d: AddVec: [%d]: expected %d, got %d", i, j, want, z[j])
+			}
+		}
+		SubVec(z, x, y)
+		for j := range z {
+			if want := x[j].Sub(y[j]); z[j] != want {
+				t.Fatalf("#%d: SubVec: [%d]: expected %d, got %d", i, j, want, z[j])
+			}
+		}
+
+		var bs, bx big.Int
+		for _, v := range x {
+			bs.Add(&bs, v.ToBig(&bx))
+		}
+		sum, carry := SumVec(x)
+		want := new(big.Int).Lsh(new(big.Int).SetUint64(carry), 256)
+		if want.Add(want, sum.ToBig(&bx)); want.Cmp(&bs) != 0 {
+			t.Fatalf("#%d: SumVec: expected %s, got %s", i, &bs, want)
+		}
+	}
+}
+
+func TestModInverseVec(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		m := randMod256(t)
+		x := randVec256(t, rand.Intn(20))
+
+		// Only keep invertible elements.
+		var bm, bx, one big.Int
+		m.ToBig(&bm)
+		one.SetInt64(1)
+		for j := 0; j < len(x); j++ {
+			if new(big.Int).GCD(nil, nil, x[j].ToBig(&bx), &bm).Cmp(&one) != 0 {
+				x = append(x[:j], x[j+1:]...)
+				j--
+			}
+		}
+
+		z := make([]Uint256, len(x))
+		if !ModInverseVec(z, x, m) {
+			t.Fatalf("#%d: ModInverseVec failed", i)
+		}
+		for j := range z {
+			want := new(big.Int).ModInverse(x[j].ToBig(&bx), &bm)
+			if cmpInt(want, z[j]) != 0 {
+				t.Fatalf("#%d: [%d]: ModInverse(%d, %d): expected %s, got %d",
+					i, j, x[j], m, want, z[j])
+			}
+		}
+
+		// In place.
+		if !ModInverseVec(x, x, m) {
+			t.Fatalf("#%d: ModInverseVec failed", i)
+		}
+		for j := range z {
+			if x[j] != z[j] {
+				t.Fatalf("#%d: [%d]: expected %d, got %d", i, j, z[j], x[j])
+			}
+		}
+	}
+
+	for _, m := range []Uint256{U256(15), U256(16)} {
+		x := []Uint256{U256(1), U256(7), U256(6), U256(11)}
+		z := []Uint256{U256(42), U256(42), U256(42), U256(42)}
+		if ModInverseVec(z, x, m) {
+			t.Fatalf("%d: expected ModInverseVec to fail", m)
+		}
+		for j := range z {
+			if z[j] != U256(42) {
+				t.Fatalf("%d: ModInverseVec modified z", m)
+			}
+		}
+	}
+}
+
+func TestVecPanic(t *testing.T) {
+	x := make([]Uint256, 2)
+	for _, tc := range []struct {
+		name string
+		fn   func()
+		msg  string
+	}{
+		{"AddVec", func() { AddVec(x[:1], x, x) }, "AddVec: length mismatch"},
+		{"SubVec", func() { SubVec(x, x, x[:1]) }, "SubVec: length mismatch"},
+		{"MulScalarVec", func() { MulScalarVec(x[:1], x, U256(1)) }, "MulScalarVec: length mismatch"},
+		{"MulModVec", func() { MulModVec(x, x, U256(1), Uint256{}) }, "division by zero"},
+		{"ModVec", func() { ModVec(x, x, Uint256{}) }, "division by zero"},
+		{"ModInverseVec", func() { ModInverseVec(x, x[:1], U256(3)) }, "ModInverseVec: length mismatch"},
+	} {
+		func() {
+			defer func() {
+				if got := recover(); got != tc.msg {
+					t.Fatalf("%s: expected panic %q, got %v", tc.name, tc.msg, got)
+				}
+			}()
+			tc.fn()
+		}()
+	}
+}
+
+func BenchmarkAddVec(b *testing.B) {
+	x, y := randVec256(b, 1024), randVec256(b, 1024)
+	z := make([]Uint256, len(x))
+
+	b.Run("loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range z {
+				z[j] = x[j].Add(y[j])
+			}
+		}
+	})
+	b.Run("vec", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			AddVec(z, x, y)
+		}
+	})
+}
+
+func BenchmarkSubVec(b *testing.B) {
+	x, y := randVec256(b, 1024), randVec256(b, 1024)
+	z := make([]Uint256, len(x))
+
+	b.Run("loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range z {
+				z[j] = x[j].Sub(y[j])
+			}
+		}
+	})
+	b.Run("vec", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			SubVec(z, x, y)
+		}
+	})
+}
+
+func BenchmarkMulScalarVec(b *testing.B) {
+	x := randVec256(b, 1024)
+	z := make([]Uint256, len(x))
+	y := randBits256(b)
+
+	b.Run("loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range z {
+				z[j] = x[j].Mul(y)
+			}
+		}
+	})
+	b.Run("vec", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			MulScalarVec(z, x, y)
+		}
+	})
+}
+
+func BenchmarkSumVec(b *testing.B) {
+	x := randVec256(b, 1024)
+
+	b.Run("loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var (
+				sum   Uint256
+				carry uint64
+			)
+			for _, v := range x {
+				var c uint64
+				sum, c = Add256(sum, v, 0)
+				carry += c
+			}
+			Sink256, sinkInt = sum, int(carry)
+		}
+	})
+	b.Run("vec", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s, c := SumVec(x)
+			Sink256, sinkInt = s, int(c)
+		}
+	})
+}
+
+func BenchmarkMulModVec(b *testing.B) {
+	x := randVec256(b, 1024)
+	z := make([]Uint256, len(x))
+	y := randBits256(b)
+	m := randBits256(b).Or(U256(1))
+
+	b.Run("loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range z {
+				z[j] = x[j].MulMod(y, m)
+			}
+		}
+	})
+	b.Run("vec", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			MulModVec(z, x, y, m)
+		}
+	})
+}
+
+func BenchmarkModInverseVec(b *testing.B) {
+	x := randVec256(b, 1024)
+	for i := range x {
+		// Make x[i] non-zero and less than m.
+		x[i] = x[i].Rsh(1).Or(U256(1))
+	}
+	z := make([]Uint256, len(x))
+	// The P-256 prime.
+	m := Uint256{0xffffffffffffffff, 0x00000000ffffffff, 0x0000000000000000, 0xffffffff00000001}
+
+	b.Run("loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range z {
+				z[j] = x[j].ModInverse(m)
+			}
+		}
+	})
+	b.Run("vec", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ModInverseVec(z, x, m)
+		}
+	})
+}
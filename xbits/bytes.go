@@ -0,0 +1,93 @@
+package xbits
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// FromBE32 returns the Uint256 whose big-endian
+// representation is b.
+func FromBE32(b [32]byte) Uint256 {
+	return Uint256{
+		binary.BigEndian.Uint64(b[24:32]),
+		binary.BigEndian.Uint64(b[16:24]),
+		binary.BigEndian.Uint64(b[8:16]),
+		binary.BigEndian.Uint64(b[0:8]),
+	}
+}
+
+// FromLE32 returns the Uint256 whose little-endian
+// representation is b.
+func FromLE32(b [32]byte) Uint256 {
+	return Uint256{
+		binary.LittleEndian.Uint64(b[0:8]),
+		binary.LittleEndian.Uint64(b[8:16]),
+		binary.LittleEndian.Uint64(b[16:24]),
+		binary.LittleEndian.Uint64(b[24:32]),
+	}
+}
+
+// Bytes32 returns the big-endian representation of x.
+func (x Uint256) Bytes32() [32]byte {
+	var b [32]byte
+	x.PutBE(b[:])
+	return b
+}
+
+// PutBE stores x into buf[:32] in big-endian order.
+//
+// If buf is smaller than 32 bytes, PutBE will panic.
+func (x Uint256) PutBE(buf []byte) {
+	if len(buf) < 32 {
+		panic("PutBE: buffer too small")
+	}
+	binary.BigEndian.PutUint64(buf[0:8], x.u3)
+	binary.BigEndian.PutUint64(buf[8:16], x.u2)
+	binary.BigEndian.PutUint64(buf[16:24], x.u1)
+	binary.BigEndian.PutUint64(buf[24:32], x.u0)
+}
+
+// PutLE stores x into buf[:32] in little-endian order.
+//
+// If buf is smaller than 32 bytes, PutLE will panic.
+func (x Uint256) PutLE(buf []byte) {
+	if len(buf) < 32 {
+		panic("PutLE: buffer too small")
+	}
+	binary.LittleEndian.PutUint64(buf[0:8], x.u0)
+	binary.LittleEndian.PutUint64(buf[8:16], x.u1)
+	binary.LittleEndian.PutUint64(buf[16:24], x.u2)
+	binary.LittleEndian.PutUint64(buf[24:32], x.u3)
+}
+
+// SetBytesChecked sets z to the big-endian unsigned
+// integer buf.
+//
+// Unlike SetBytes, buf may be longer than 32 bytes as long
+// as the extra leading bytes are zero. If buf overflows z
+// (buf > 1<<256-1), SetBytesChecked returns an error and
+// leaves z unchanged.
+func (z *Uint256) SetBytesChecked(buf []byte) error {
+	n := len(buf)
+	for len(buf) > 32 && buf[0] == 0 {
+		buf = buf[1:]
+	}
+	if len(buf) > 32 {
+		return fmt.Errorf("xbits: SetBytesChecked: integer too large: %d bytes", n)
+	}
+	z.SetBytes(buf)
+	return nil
+}
+
+// SetBytesLE sets z to the little-endian unsigned integer
+// buf.
+//
+// SetBytesLE panics if buf overflows z (buf > 1<<256-1).
+func (z *Uint256) SetBytesLE(buf []byte) {
+	if len(buf) > 32 {
+		panic("SetBytesLE: integer too large")
+	}
+	var b [32]byte
+	copy(b[:], buf)
+	*z = FromLE32(b)
+}
@@ -0,0 +1,130 @@
+package xbits
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// reverse returns b with its bytes in reverse order.
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i, c := range b {
+		r[len(b)-1-i] = c
+	}
+	return r
+}
+
+func TestBytes256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x := randBits256(t)
+
+		var bx big.Int
+		setInt(&bx, x)
+		want := bx.FillBytes(make([]byte, 32))
+
+		be := x.Bytes32()
+		if !bytes.Equal(be[:], want) {
+			t.Fatalf("#%d: Bytes32: expected %x, got %x", i, want, be)
+		}
+		if got := FromBE32(be); got != x {
+			t.Fatalf("#%d: FromBE32: expected %d, got %d", i, x, got)
+		}
+
+		buf := make([]byte, 40)
+		x.PutBE(buf)
+		if !bytes.Equal(buf[:32], want) || !bytes.Equal(buf[32:], make([]byte, 8)) {
+			t.Fatalf("#%d: PutBE: expected %x, got %x", i, want, buf)
+		}
+
+		x.PutLE(buf)
+		if !bytes.Equal(buf[:32], reverse(want)) {
+			t.Fatalf("#%d: PutLE: expected %x, got %x", i, reverse(want), buf[:32])
+		}
+		var le [32]byte
+		copy(le[:], buf)
+		if got := FromLE32(le); got != x {
+			t.Fatalf("#%d: FromLE32: expected %d, got %d", i, x, got)
+		}
+
+		// Minimal encodings round trip.
+		var z Uint256
+		z.SetBytesLE(reverse(bx.Bytes()))
+		if z != x {
+			t.Fatalf("#%d: SetBytesLE: expected %d, got %d", i, x, z)
+		}
+		z = Uint256{}
+		if err := z.SetBytesChecked(bx.Bytes()); err != nil {
+			t.Fatalf("#%d: SetBytesChecked: %v", i, err)
+		}
+		if z != x {
+			t.Fatalf("#%d: SetBytesChecked: expected %d, got %d", i, x, z)
+		}
+
+		// Leading zeros are ignored by SetBytesChecked.
+		padded := append(make([]byte, rand.Intn(32)), want...)
+		z = Uint256{}
+		if err := z.SetBytesChecked(padded); err != nil {
+			t.Fatalf("#%d: SetBytesChecked: %v", i, err)
+		}
+		if z != x {
+			t.Fatalf("#%d: SetBytesChecked: expected %d, got %d", i, x, z)
+		}
+	}
+}
+
+func TestFillBytes256Extend(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		x := randBits256(t)
+
+		var bx big.Int
+		setInt(&bx, x)
+		n := 32 + rand.Intn(32)
+		want := bx.FillBytes(make([]byte, n))
+
+		buf := bytes.Repeat([]byte{0xff}, n)
+		if got := x.FillBytes(buf); !bytes.Equal(got, want) {
+			t.Fatalf("#%d: expected %x, got %x", i, want, got)
+		}
+	}
+}
+
+func TestSetBytesChecked256(t *testing.T) {
+	for i, buf := range [][]byte{
+		append([]byte{1}, make([]byte, 32)...),
+		append(make([]byte, 10), append([]byte{1}, make([]byte, 32)...)...),
+		bytes.Repeat([]byte{0xff}, 64),
+	} {
+		z := U256(42)
+		if err := z.SetBytesChecked(buf); err == nil {
+			t.Fatalf("#%d: expected an error", i)
+		}
+		if z != U256(42) {
+			t.Fatalf("#%d: SetBytesChecked modified z", i)
+		}
+	}
+}
+
+func TestBytes256Panic(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		fn   func()
+		msg  string
+	}{
+		{"FillBytes", func() { max256.FillBytes(make([]byte, 31)) }, "FillBytes: buffer too small"},
+		{"PutBE", func() { max256.PutBE(make([]byte, 31)) }, "PutBE: buffer too small"},
+		{"PutLE", func() { max256.PutLE(make([]byte, 31)) }, "PutLE: buffer too small"},
+		{"SetBytes", func() { new(Uint256).SetBytes(make([]byte, 33)) }, "SetBytes: integer too large"},
+		{"SetBytesLE", func() { new(Uint256).SetBytesLE(make([]byte, 33)) }, "SetBytesLE: integer too large"},
+	} {
+		func() {
+			defer func() {
+				if got := recover(); got != tc.msg {
+					t.Fatalf("%s: expected panic %q, got %v", tc.name, tc.msg, got)
+				}
+			}()
+			tc.fn()
+		}()
+	}
+}
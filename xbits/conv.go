@@ -0,0 +1,110 @@
+package xbits
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// FromBig converts b to a Uint256 and reports whether b
+// can be represented as a Uint256.
+//
+// If b is negative or b > 1<<256-1, FromBig returns b
+// modulo 2^256 and false.
+func FromBig(b *big.Int) (Uint256, bool) {
+	var w [4]uint64
+	ok := bigWords(w[:], b)
+	x := Uint256{w[0], w[1], w[2], w[3]}
+	if b.Sign() < 0 {
+		return Uint256{}.Sub(x), false
+	}
+	return x, ok
+}
+
+// ToBig sets z to x and returns z.
+func (x Uint256) ToBig(z *big.Int) *big.Int {
+	setInt(z, x)
+	return z
+}
+
+// bigWords sets z to the least significant len(z) words of
+// |x| and reports whether |x| fits in len(z) words.
+func bigWords(z []uint64, x *big.Int) bool {
+	for i := range z {
+		z[i] = 0
+	}
+	const _W = bits.UintSize
+	words := x.Bits()
+	if _W == 64 {
+		for i := 0; i < len(words) && i < len(z); i++ {
+			z[i] = uint64(words[i])
+		}
+		return len(words) <= len(z)
+	}
+	// _W == 32
+	for i := 0; i < len(words) && i/2 < len(z); i++ {
+		z[i/2] |= uint64(words[i]) << (32 * uint(i%2))
+	}
+	return len(words) <= 2*len(z)
+}
+
+// IsUint64 reports whether x can be represented as
+// a uint64.
+func (x Uint256) IsUint64() bool {
+	return x.u1|x.u2|x.u3 == 0
+}
+
+// Uint64WithOverflow returns the uint64 representation of
+// x and reports whether x overflowed a uint64.
+//
+// If x overflows, the result is x modulo 2^64.
+func (x Uint256) Uint64WithOverflow() (uint64, bool) {
+	return x.u0, !x.IsUint64()
+}
+
+// Float64 returns the float64 value nearest to x, using
+// IEEE 754 round-half-to-even.
+//
+// Every Uint256 is within the range of a float64, so the
+// result is always finite.
+func (x Uint256) Float64() float64 {
+	n := x.BitLen()
+	if n <= 64 {
+		return float64(x.u0)
+	}
+	// Take the 64 most significant bits and fold the
+	// remaining bits into a sticky bit. The sticky bit is
+	// well below the 53-bit mantissa, so converting m
+	// rounds the same way as converting x.
+	s := uint(n - 64)
+	m := x.Rsh(s).u0
+	if x.TrailingZeros() < int(s) {
+		m |= 1
+	}
+	return math.Ldexp(float64(m), int(s))
+}
+
+// FromFloat64 converts f to a Uint256, truncating toward
+// zero like a Go conversion, and reports whether the
+// integer part of f can be represented as a Uint256.
+//
+// If f is NaN, f <= -1, or f >= 2^256, FromFloat64 returns
+// (0, false).
+func FromFloat64(f float64) (Uint256, bool) {
+	switch {
+	case math.IsNaN(f), f <= -1, f >= 0x1p256:
+		return Uint256{}, false
+	case f < 0x1p64:
+		if f < 0 {
+			// (-1, 0) truncates to zero.
+			return Uint256{}, true
+		}
+		return U256(uint64(f)), true
+	}
+	// f is in [2^64, 2^256), so it is an integer and its
+	// exponent is at least 64.
+	b := math.Float64bits(f)
+	exp := uint(b>>52&0x7ff) - 1023 - 52
+	mant := b&(1<<52-1) | 1<<52
+	return U256(mant).Lsh(exp), true
+}
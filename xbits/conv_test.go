@@ -0,0 +1,144 @@
+package xbits
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestBig256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x := randBits256(t)
+
+		var bx big.Int
+		if got := x.ToBig(&bx); got != &bx || cmpInt(&bx, x) != 0 {
+			t.Fatalf("#%d: ToBig: expected %d, got %s", i, x, &bx)
+		}
+		z, ok := FromBig(&bx)
+		if !ok || z != x {
+			t.Fatalf("#%d: FromBig(%s): expected (%d, true), got (%d, %t)", i, &bx, x, z, ok)
+		}
+
+		// Out of range values wrap.
+		for _, b := range []*big.Int{
+			new(big.Int).Neg(&bx),
+			new(big.Int).Add(&bx, big256),
+			new(big.Int).Add(&bx, new(big.Int).Lsh(big256, uint(rand.Intn(256)))),
+		} {
+			want := new(big.Int).Mod(b, big256)
+			z, ok := FromBig(b)
+			if wantOK := b.Cmp(want) == 0; ok != wantOK {
+				t.Fatalf("#%d: FromBig(%s): expected %t, got %t", i, b, wantOK, ok)
+			}
+			if cmpInt(want, z) != 0 {
+				t.Fatalf("#%d: FromBig(%s): expected %s, got %d", i, b, want, z)
+			}
+		}
+	}
+}
+
+func TestUint64WithOverflow(t *testing.T) {
+	for i, tc := range []struct {
+		x        Uint256
+		v        uint64
+		overflow bool
+	}{
+		{Uint256{}, 0, false},
+		{U256(math.MaxUint64), math.MaxUint64, false},
+		{Uint256{0, 1, 0, 0}, 0, true},
+		{Uint256{42, 0, 0, 1}, 42, true},
+		{max256, math.MaxUint64, true},
+	} {
+		v, overflow := tc.x.Uint64WithOverflow()
+		if v != tc.v || overflow != tc.overflow {
+			t.Fatalf("#%d: expected (%d, %t), got (%d, %t)", i, tc.v, tc.overflow, v, overflow)
+		}
+		if tc.x.IsUint64() == tc.overflow {
+			t.Fatalf("#%d: IsUint64: expected %t", i, !tc.overflow)
+		}
+	}
+}
+
+func TestFloat64(t *testing.T) {
+	check := func(i int, x Uint256) {
+		t.Helper()
+		var bx big.Int
+		want, _ := new(big.Float).SetInt(x.ToBig(&bx)).Float64()
+		if got := x.Float64(); got != want {
+			t.Fatalf("#%d: Float64(%d): expected %g, got %g", i, x, want, got)
+		}
+	}
+	for i := 0; i < 100_000; i++ {
+		check(i, randBits256(t))
+	}
+
+	// Exercise the rounding boundaries: for each bit length,
+	// values just below, at, and just above a tie.
+	for n := uint(54); n <= 256; n++ {
+		s := n - 54
+		for _, lo := range []Uint256{
+			U256(1).Lsh(s).Sub(U256(1)),
+			U256(1).Lsh(s),
+			U256(1).Lsh(s).Add(U256(1)),
+		} {
+			if n == 54 && lo == (Uint256{}) {
+				continue
+			}
+			for _, m := range []uint64{1 << 53, 1<<53 + 1, 1<<54 - 1} {
+				x := U256(m).Lsh(s + 1).Or(lo)
+				check(int(n), x)
+			}
+		}
+	}
+	check(0, max256)
+	if got, want := max256.Float64(), 0x1p256; got != want {
+		t.Fatalf("expected %g, got %g", want, got)
+	}
+}
+
+func TestFromFloat64(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		f := math.Ldexp(rand.Float64(), rand.Intn(257))
+		z, ok := FromFloat64(f)
+		if !ok {
+			t.Fatalf("#%d: FromFloat64(%g): unexpected overflow", i, f)
+		}
+		want, _ := new(big.Float).SetFloat64(f).Int(nil)
+		if cmpInt(want, z) != 0 {
+			t.Fatalf("#%d: FromFloat64(%g): expected %s, got %d", i, f, want, z)
+		}
+		// Integers round trip.
+		if f >= 1 && z.Float64() != math.Trunc(f) {
+			t.Fatalf("#%d: expected %g, got %g", i, math.Trunc(f), z.Float64())
+		}
+	}
+
+	for i, tc := range []struct {
+		f  float64
+		x  Uint256
+		ok bool
+	}{
+		{0, Uint256{}, true},
+		{math.Copysign(0, -1), Uint256{}, true},
+		{-0.5, Uint256{}, true},
+		{0.999, Uint256{}, true},
+		{1.5, U256(1), true},
+		{0x1p63, U256(1 << 63), true},
+		{0x1p64, Uint256{0, 1, 0, 0}, true},
+		{0x1p255, U256(1).Lsh(255), true},
+		{math.Nextafter(0x1p256, 0), max256.Rsh(203).Lsh(203), true},
+		{-1, Uint256{}, false},
+		{0x1p256, Uint256{}, false},
+		{math.MaxFloat64, Uint256{}, false},
+		{math.Inf(+1), Uint256{}, false},
+		{math.Inf(-1), Uint256{}, false},
+		{math.NaN(), Uint256{}, false},
+	} {
+		x, ok := FromFloat64(tc.f)
+		if x != tc.x || ok != tc.ok {
+			t.Fatalf("#%d: FromFloat64(%g): expected (%d, %t), got (%d, %t)",
+				i, tc.f, tc.x, tc.ok, x, ok)
+		}
+	}
+}
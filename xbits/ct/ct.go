@@ -1,7 +1,5 @@
 package ct
 
-import "fmt"
-
 // LessOrEq returns 1 if x <= y and 0 otherwise.
 //
 // See golang.org/issues/42685.
@@ -96,7 +94,8 @@ func Equal32(x, y uint32) uint32 {
 
 // Equal64 returns 1 if x == y and 0 otherwise.
 func Equal64(x, y uint64) uint64 {
-	return (uint64(x^y) - 1) >> 63
+	z := x ^ y
+	return ((z | -z) >> 63) ^ 1
 }
 
 // Select returns x if v == 1 and y if v == 0.
@@ -115,7 +114,13 @@ func Select64(v, x, y uint64) uint64 {
 	return ^(v-1)&x | (v-1)&y
 }
 
-// Div64 returns q = (hi, lo) / d and r = (hi, lo) % d.
+// Div32 returns q = (hi, lo) / d and r = (hi, lo) % d.
+//
+// hi must be less than or equal to d. If hi == d, the
+// quotient does not fit in 32 bits and is truncated.
+//
+// This function's execution time does not depend on its
+// inputs.
 func Div32(hi, lo, d uint32) (q, r uint32) {
 	ch := Equal32(hi, d)
 	hi = Select32(ch, 0, hi)
@@ -136,6 +141,12 @@ func Div32(hi, lo, d uint32) (q, r uint32) {
 }
 
 // Div64 returns q = (hi, lo) / d and r = (hi, lo) % d.
+//
+// hi must be less than or equal to d. If hi == d, the
+// quotient does not fit in 64 bits and is truncated.
+//
+// This function's execution time does not depend on its
+// inputs.
 func Div64(hi, lo, d uint64) (q, r uint64) {
 	ch := Equal64(hi, d)
 	hi = Select64(ch, 0, hi)
@@ -145,7 +156,6 @@ func Div64(hi, lo, d uint64) (q, r uint64) {
 		ctl := GreaterEq64(w, d) | (hi >> k)
 		hi2 := (w - d) >> j
 		lo2 := lo - (d << k)
-		fmt.Printf("lo=%d lo2=%d d=%d k=%d\n", lo, lo2, d, k)
 		hi = Select64(ctl, hi2, hi)
 		lo = Select64(ctl, lo2, lo)
 		q |= ctl << k
@@ -2,6 +2,7 @@ package ct
 
 import (
 	"math/bits"
+	"math/rand"
 	"testing"
 )
 
@@ -70,3 +71,44 @@ func TestMulDiv32(t *testing.T) {
 		testDiv("Div32 symmetric", Div32, a.hi, a.lo+a.r, a.x, a.y, a.r)
 	}
 }
+
+func TestDiv64Random(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		d := rand.Uint64() >> uint(rand.Intn(64))
+		if d == 0 {
+			d = 1
+		}
+		hi := rand.Uint64() % d
+		lo := rand.Uint64()
+
+		q, r := Div64(hi, lo, d)
+		wq, wr := bits.Div64(hi, lo, d)
+		if q != wq || r != wr {
+			t.Fatalf("#%d: Div64(%#x, %#x, %#x): expected (%#x, %#x), got (%#x, %#x)",
+				i, hi, lo, d, wq, wr, q, r)
+		}
+	}
+}
+
+func TestEqual64(t *testing.T) {
+	for i, tc := range []struct {
+		x, y uint64
+		want uint64
+	}{
+		{0, 0, 1},
+		{1, 1, 1},
+		{_M64, _M64, 1},
+		{0, 1, 0},
+		{1, 0, 0},
+		{0, 1 << 63, 0},
+		{0, 1<<63 + 1, 0},
+		{0, _M64, 0},
+		{_M64, 0, 0},
+	} {
+		got := Equal64(tc.x, tc.y)
+		if got != tc.want {
+			t.Fatalf("#%d: Equal64(%#x, %#x): expected %d, got %d",
+				i, tc.x, tc.y, tc.want, got)
+		}
+	}
+}
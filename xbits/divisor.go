@@ -0,0 +1,135 @@
+package xbits
+
+import "math/bits"
+
+// Divisor divides by a fixed Uint256.
+//
+// Dividing by a Divisor is faster than calling QuoRem with
+// the same divisor because the normalization shift and the
+// reciprocal used by divWW are computed once, ahead of
+// time. See "Improved Division by Invariant Integers" by
+// Möller and Granlund.
+//
+// A Divisor is safe for concurrent use.
+type Divisor struct {
+	d Uint256
+	// v is d<<shift, so the high bit of v[n-1] is set.
+	v [4]uint64
+	// n is the number of words in d.
+	n     int
+	shift uint
+	// rec is reciprocal(v[n-1]).
+	rec uint64
+}
+
+// NewDivisor creates a Divisor for d.
+//
+// NewDivisor panics if d == 0.
+func NewDivisor(d Uint256) *Divisor {
+	z := new(Divisor)
+	z.init(d)
+	return z
+}
+
+// init initializes z for the divisor d.
+func (z *Divisor) init(d Uint256) {
+	n := (d.BitLen() + 63) / 64
+	if n == 0 {
+		panic("division by zero")
+	}
+	z.d = d
+	z.n = n
+	z.v = d.words()
+	z.shift = uint(bits.LeadingZeros64(z.v[n-1]))
+	shl(z.v[:n], z.v[:n], z.shift)
+	z.rec = reciprocal(z.v[n-1])
+}
+
+// Divisor returns the divisor d.
+func (z *Divisor) Divisor() Uint256 {
+	return z.d
+}
+
+// Quo returns x / d.
+func (z *Divisor) Quo(x Uint256) Uint256 {
+	q, _ := z.QuoRem(x)
+	return q
+}
+
+// Rem returns x % d.
+func (z *Divisor) Rem(x Uint256) Uint256 {
+	w := x.words()
+	var u [5]uint64
+	return z.div(nil, u[:], w[:])
+}
+
+// QuoRem returns x / d and x % d.
+//
+// See QuoRemCT for a constant-time version.
+func (z *Divisor) QuoRem(x Uint256) (Uint256, Uint256) {
+	w := x.words()
+	var u [5]uint64
+	var q [4]uint64
+	r := z.div(q[:], u[:], w[:])
+	return u256(q), r
+}
+
+// Mod512 returns x % d.
+func (z *Divisor) Mod512(x Uint512) Uint256 {
+	w := [8]uint64{x.u0, x.u1, x.u2, x.u3, x.u4, x.u5, x.u6, x.u7}
+	var u [9]uint64
+	return z.div(nil, u[:], w[:])
+}
+
+// div sets q = x/d and returns x%d.
+//
+// div has the following conditions:
+//
+//	len(x) >= 4
+//	len(u) == len(x) + 1
+//	len(q) >= len(x) - z.n + 1, or q == nil
+//	q must be zero
+//
+// If q is nil, the quotient is discarded. div uses u as
+// scratch space.
+func (z *Divisor) div(q, u, x []uint64) Uint256 {
+	ul := len(x)
+	for ul > 0 && x[ul-1] == 0 {
+		ul--
+	}
+	if ul < z.n {
+		// x < d, so q = 0 and r = x.
+		return Uint256{x[0], x[1], x[2], x[3]}
+	}
+
+	// D1. v is already normalized, so only shift u.
+	u = u[:ul+1]
+	u[ul] = shl(u[:ul], x[:ul], z.shift)
+
+	if z.n == 1 {
+		// Short division. u[ul] < v[0] since v[0] has its
+		// high bit set.
+		v := z.v[0]
+		r := u[ul]
+		for i := ul - 1; i >= 0; i-- {
+			var qi uint64
+			qi, r = divWW(r, u[i], v, z.rec)
+			if q != nil {
+				q[i] = qi
+			}
+		}
+		return U256(r >> z.shift)
+	}
+
+	var qbuf [8]uint64
+	if q == nil {
+		q = qbuf[:]
+	}
+	divNorm(q[:ul-z.n+1], u, z.v[:z.n], z.rec)
+
+	r := u[:z.n]
+	shr(r, r, z.shift)
+	var w [4]uint64
+	copy(w[:], r)
+	return u256(w)
+}
@@ -0,0 +1,173 @@
+package xbits
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// divisorEdges are divisors that exercise the q̂
+// corrections and the word-count boundaries.
+var divisorEdges = []Uint256{
+	U256(1),
+	U256(2),
+	U256(3),
+	U256(math.MaxUint64),
+	{0, 1, 0, 0},
+	{math.MaxUint64, math.MaxUint64, 0, 0},
+	{1, 0, 0, math.MaxUint64},
+	{math.MaxUint64, 0, 0, math.MaxUint64},
+	{0, 0, 0, 1 << 63},
+	{1, 0, 0, 1 << 63},
+	max256,
+	max256.Rsh(1),
+	max256.Rsh(63),
+	max256.Rsh(65),
+}
+
+func TestDivisor(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		var d Uint256
+		if i < len(divisorEdges) {
+			d = divisorEdges[i]
+		} else {
+			d = randBits256(t)
+			if d.BitLen() == 0 {
+				d = U256(1)
+			}
+		}
+		dd := NewDivisor(d)
+		if got := dd.Divisor(); got != d {
+			t.Fatalf("#%d: Divisor: expected %d, got %d", i, d, got)
+		}
+
+		for j := 0; j < 10; j++ {
+			x := randBits256(t)
+			switch j {
+			case 0:
+				x = Uint256{}
+			case 1:
+				x = max256
+			case 2:
+				x = d
+			case 3:
+				x = d.Sub(U256(1))
+			}
+			wq, wr := x.QuoRem(d)
+			q, r := dd.QuoRem(x)
+			if q != wq || r != wr {
+				t.Fatalf("#%d: QuoRem(%d, %d): expected (%d, %d), got (%d, %d)",
+					i, x, d, wq, wr, q, r)
+			}
+			if got := dd.Quo(x); got != wq {
+				t.Fatalf("#%d: Quo(%d, %d): expected %d, got %d", i, x, d, wq, got)
+			}
+			if got := dd.Rem(x); got != wr {
+				t.Fatalf("#%d: Rem(%d, %d): expected %d, got %d", i, x, d, wr, got)
+			}
+		}
+	}
+}
+
+func TestDivisorMod512(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		var d Uint256
+		if i < len(divisorEdges) {
+			d = divisorEdges[i]
+		} else {
+			d = randBits256(t)
+			if d.BitLen() == 0 {
+				d = U256(1)
+			}
+		}
+		dd := NewDivisor(d)
+
+		for j := 0; j < 10; j++ {
+			x := randBits256(t).MulFull(randBits256(t))
+			switch j {
+			case 0:
+				x = Uint512{}
+			case 1:
+				x = max256.MulFull(max256)
+			case 2:
+				x = U256(rand.Uint64()).MulFull(d)
+			}
+			var bx, bd, want big.Int
+			setInt512(&bx, x)
+			setInt(&bd, d)
+			want.Mod(&bx, &bd)
+			if got := dd.Mod512(x); cmpInt(&want, got) != 0 {
+				t.Fatalf("#%d: Mod512(%d, %d): expected %s, got %d",
+					i, x, d, &want, got)
+			}
+		}
+	}
+}
+
+func TestDivisorZero(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	NewDivisor(Uint256{})
+}
+
+func TestDivisorAllocs(t *testing.T) {
+	x := max256
+	for _, d := range divisorEdges {
+		n := testing.AllocsPerRun(100, func() {
+			dd := NewDivisor(d)
+			Sink256, Sink256 = dd.QuoRem(x)
+			Sink256 = dd.Mod512(x.MulFull(x))
+		})
+		if n != 0 {
+			t.Fatalf("%d: expected 0 allocations, got %.1f", d, n)
+		}
+	}
+}
+
+func BenchmarkDivisorQuoRem(b *testing.B) {
+	x, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	y, err := Rand256(rng, max128)
+	if err != nil {
+		b.Fatal(err)
+	}
+	d := NewDivisor(y)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink256, Sink256 = d.QuoRem(x)
+	}
+}
+
+func BenchmarkDivisorQuoRemSmall(b *testing.B) {
+	x, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	y, err := Rand256(rng, U256(math.MaxUint64))
+	if err != nil {
+		b.Fatal(err)
+	}
+	if y.BitLen() == 0 {
+		y = U256(1)
+	}
+	d := NewDivisor(y)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink256, Sink256 = d.QuoRem(x)
+	}
+}
+
+func BenchmarkDivisorMod512(b *testing.B) {
+	x := max256.MulFull(max256.Rsh(1))
+	d := NewDivisor(max256.Rsh(3))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink256 = d.Mod512(x)
+	}
+}
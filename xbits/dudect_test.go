@@ -0,0 +1,186 @@
+package xbits
+
+import (
+	"bytes"
+	"flag"
+	"runtime"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/elagergren/ctb/dudect"
+)
+
+// The dudect tests are statistical and need a quiet machine,
+// so they only run when requested. For example:
+//
+//	GODEBUG=asyncpreemptoff=1 go test -run Dudect -dudect 1m
+var dudectFlag = flag.Duration("dudect", 0, "run dudect tests for the provided duration")
+
+// testDudect runs fn through dudect and fails if it finds
+// a timing leak.
+//
+// Each input is size bytes. Per dudect.Prepare, the inputs
+// for one class are all zero and the inputs for the other
+// are random.
+func testDudect(t *testing.T, size int, fn func(data []byte)) {
+	if *dudectFlag <= 0 {
+		t.Skip("skipping dudect test; use -dudect to enable")
+	}
+
+	defer debug.SetGCPercent(debug.SetGCPercent(-1))
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	cfg := &dudect.Config{
+		ChunkSize:    size,
+		Measurements: 10_000,
+	}
+	if testing.Verbose() {
+		cfg.Output = testWriter{t}
+	}
+	ctx := dudect.NewContext(cfg)
+	test := func(data []byte) bool {
+		fn(data)
+		return true
+	}
+	deadline := time.Now().Add(*dudectFlag)
+	for time.Now().Before(deadline) {
+		if ctx.Test(test, nil) {
+			t.Fatal("timing leak detected")
+		}
+	}
+}
+
+// testWriter is an io.Writer that writes to t.Log.
+type testWriter struct {
+	t *testing.T
+}
+
+func (w testWriter) Write(p []byte) (int, error) {
+	w.t.Log(string(p))
+	return len(p), nil
+}
+
+func TestExpCTDudect(t *testing.T) {
+	x, err := Rand256(rng, max256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []Uint256{
+		// Odd moduli use Montgomery multiplication.
+		{0xffffffffffffffff, 0x00000000ffffffff, 0x0000000000000000, 0xffffffff00000001},
+		// Even moduli use Barrett reduction.
+		{0xfffffffffffffffe, 0x00000000ffffffff, 0x0000000000000000, 0xffffffff00000001},
+	} {
+		testDudect(t, 32, func(data []byte) {
+			var y Uint256
+			y.SetBytes(data)
+			Sink256 = x.ExpCT(y, m)
+		})
+	}
+}
+
+func TestQuoRemCTDudect(t *testing.T) {
+	x, err := Rand256(rng, max256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, err := Rand256(rng, max128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y = y.Or(U256(1))
+
+	// Secret dividend.
+	testDudect(t, 32, func(data []byte) {
+		var x Uint256
+		x.SetBytes(data)
+		Sink256, _ = x.QuoRemCT(y)
+	})
+	// Secret divisor. Set the low bit so it is never zero.
+	testDudect(t, 32, func(data []byte) {
+		var y Uint256
+		y.SetBytes(data)
+		Sink256, _ = x.QuoRemCT(y.Or(U256(1)))
+	})
+}
+
+func TestMulModCTDudect(t *testing.T) {
+	x, err := Rand256(rng, max256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Secret multiplicand and modulus.
+	testDudect(t, 64, func(data []byte) {
+		var y, m Uint256
+		y.SetBytes(data[:32])
+		m.SetBytes(data[32:])
+		Sink256 = x.MulModCT(y, m.Or(U256(1)))
+	})
+}
+
+func TestBarrettDudect(t *testing.T) {
+	x, err := Rand256(rng, max256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []Uint256{
+		// Normalized and unnormalized moduli take different
+		// paths through Reduce512.
+		max256.Lsh(1),
+		max256.Lsh(1).Rsh(7),
+	} {
+		z := NewBarrett(m)
+		testDudect(t, 32, func(data []byte) {
+			var y Uint256
+			y.SetBytes(data)
+			Sink256 = z.MulMod(x, y)
+		})
+	}
+}
+
+func TestCmpCTDudect(t *testing.T) {
+	x, err := Rand256(rng, max256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testDudect(t, 32, func(data []byte) {
+		var y Uint256
+		y.SetBytes(data)
+		lt, eq := x.CmpCT(y)
+		sinkInt = int(lt<<1 | eq)
+		sinkInt += y.BitLen() + y.TrailingZeros()
+		sinkInt += int(y.Bit(int(data[0])))
+	})
+}
+
+var sinkInt int
+
+func TestSqrDudect(t *testing.T) {
+	testDudect(t, 32, func(data []byte) {
+		var x Uint256
+		x.SetBytes(data)
+		Sink512 = x.SqrFull()
+		Sink256 = x.Sqr()
+	})
+}
+
+func TestFieldSqrtDudect(t *testing.T) {
+	for _, f := range []*Field{P256(), P256Scalar()} {
+		testDudect(t, 32, func(data []byte) {
+			var x Uint256
+			x.SetBytes(data)
+			z, ok := f.NewElement(x).Sqrt()
+			Sink256 = z.v
+			sinkInt = int(ok) + z.Legendre()
+		})
+	}
+}
+
+func TestRandModReducedDudect(t *testing.T) {
+	max := max256.Rsh(3)
+	testDudect(t, 40, func(data []byte) {
+		Sink256, _ = RandModReduced(bytes.NewReader(data), max)
+	})
+}
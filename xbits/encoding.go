@@ -0,0 +1,196 @@
+package xbits
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = Uint256{}
+	_ encoding.BinaryUnmarshaler = (*Uint256)(nil)
+	_ encoding.TextMarshaler     = Uint256{}
+	_ encoding.TextUnmarshaler   = (*Uint256)(nil)
+	_ json.Marshaler             = Uint256{}
+	_ json.Unmarshaler           = (*Uint256)(nil)
+	_ driver.Valuer              = Uint256{}
+
+	_ encoding.TextMarshaler   = HexUint256{}
+	_ encoding.TextUnmarshaler = (*HexUint256)(nil)
+
+	_ json.Marshaler   = NumberUint256{}
+	_ json.Unmarshaler = (*NumberUint256)(nil)
+
+	_ driver.Valuer = NullUint256{}
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// The result is always 32 bytes, the big-endian
+// representation of x.
+func (x Uint256) MarshalBinary() ([]byte, error) {
+	return x.FillBytes(make([]byte, 32)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// The data must be exactly 32 bytes, as produced by
+// MarshalBinary.
+func (z *Uint256) UnmarshalBinary(data []byte) error {
+	if len(data) != 32 {
+		return fmt.Errorf("xbits: UnmarshalBinary: invalid length: %d", len(data))
+	}
+	z.SetBytes(data)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+//
+// The result is the decimal representation of x. Use
+// HexUint256 for hexadecimal.
+func (x Uint256) MarshalText() ([]byte, error) {
+	return x.AppendText(nil, 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+//
+// The text is interpreted as if by ParseUint256 with base
+// 0, so it can be decimal or have a base prefix like "0x".
+func (z *Uint256) UnmarshalText(text []byte) error {
+	return z.setString(string(text), 0, "UnmarshalText")
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The result is a quoted decimal string since many JSON
+// decoders cannot represent 256-bit numbers. Use
+// NumberUint256 for a JSON number.
+func (x Uint256) MarshalJSON() ([]byte, error) {
+	// 78 decimal digits plus two quotes.
+	b := append(make([]byte, 0, 80), '"')
+	b = x.AppendText(b, 10)
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// It accepts both JSON numbers and JSON strings. Strings
+// are interpreted like UnmarshalText. Following the json
+// package's convention, null is a no-op.
+func (z *Uint256) UnmarshalJSON(data []byte) error {
+	return z.unmarshalJSON(data, "UnmarshalJSON")
+}
+
+func (z *Uint256) unmarshalJSON(data []byte, fn string) error {
+	s, base, ok := jsonText(data)
+	if !ok {
+		return nil
+	}
+	return z.setString(s, base, fn)
+}
+
+// jsonText returns the text of the JSON number or string
+// data and the base to parse it with. It returns false if
+// data is null.
+func jsonText(data []byte) (s string, base int, ok bool) {
+	if string(data) == "null" {
+		return "", 0, false
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		return string(data[1 : len(data)-1]), 0, true
+	}
+	return string(data), 10, true
+}
+
+// Value implements driver.Valuer.
+//
+// The result is the decimal representation of x.
+//
+// See NullUint256.
+func (x Uint256) Value() (driver.Value, error) {
+	return x.String(), nil
+}
+
+// HexUint256 is a Uint256 that marshals to text as a
+// hexadecimal string with a "0x" prefix.
+//
+// It unmarshals from the same inputs as Uint256.
+type HexUint256 Uint256
+
+// MarshalText implements encoding.TextMarshaler.
+func (x HexUint256) MarshalText() ([]byte, error) {
+	return Uint256(x).AppendText([]byte("0x"), 16), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (z *HexUint256) UnmarshalText(text []byte) error {
+	return (*Uint256)(z).setString(string(text), 0, "UnmarshalText")
+}
+
+// NumberUint256 is a Uint256 that marshals to JSON as a
+// number instead of a string.
+//
+// It unmarshals from the same inputs as Uint256.
+type NumberUint256 Uint256
+
+// MarshalJSON implements json.Marshaler.
+func (x NumberUint256) MarshalJSON() ([]byte, error) {
+	return Uint256(x).AppendText(nil, 10), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (z *NumberUint256) UnmarshalJSON(data []byte) error {
+	return (*Uint256)(z).unmarshalJSON(data, "UnmarshalJSON")
+}
+
+// NullUint256 is a Uint256 that may be null.
+//
+// NullUint256 implements sql.Scanner and driver.Valuer
+// so it can be used as a scan destination, similar to
+// sql.NullInt64. Uint256 itself cannot implement
+// sql.Scanner because its Scan method implements
+// fmt.Scanner, so use NullUint256 to read a Uint256 from
+// a database:
+//
+//	var n xbits.NullUint256
+//	err := rows.Scan(&n)
+type NullUint256 struct {
+	Uint256 Uint256
+	Valid   bool // Valid is true if Uint256 is not NULL
+}
+
+// Scan implements sql.Scanner.
+//
+// It accepts nil, non-negative int64 values, and strings
+// or byte slices that UnmarshalText accepts.
+func (n *NullUint256) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		n.Uint256, n.Valid = Uint256{}, false
+		return nil
+	case int64:
+		if v < 0 {
+			err = fmt.Errorf("xbits: Scan: negative value: %d", v)
+		} else {
+			n.Uint256 = U256(uint64(v))
+		}
+	case string:
+		err = n.Uint256.setString(v, 0, "Scan")
+	case []byte:
+		err = n.Uint256.setString(string(v), 0, "Scan")
+	default:
+		err = fmt.Errorf("xbits: Scan: unsupported type: %T", src)
+	}
+	n.Valid = err == nil
+	return err
+}
+
+// Value implements driver.Valuer.
+func (n NullUint256) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Uint256.Value()
+}
@@ -0,0 +1,225 @@
+package xbits
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+var _ sql.Scanner = (*NullUint256)(nil)
+
+func randBits256(t testing.TB) Uint256 {
+	x, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return x
+}
+
+func TestMarshalBinary256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x := randBits256(t)
+		b, err := x.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(b) != 32 {
+			t.Fatalf("#%d: expected 32 bytes, got %d", i, len(b))
+		}
+		var z Uint256
+		if err := z.UnmarshalBinary(b); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if z != x {
+			t.Fatalf("#%d: expected %d, got %d", i, x, z)
+		}
+	}
+
+	b, _ := U256(0x0102).MarshalBinary()
+	want := make([]byte, 32)
+	want[30], want[31] = 1, 2
+	if !bytes.Equal(b, want) {
+		t.Fatalf("expected %x, got %x", want, b)
+	}
+
+	for _, n := range []int{0, 1, 31, 33, 64} {
+		z := U256(42)
+		if err := z.UnmarshalBinary(make([]byte, n)); err == nil {
+			t.Fatalf("%d: expected an error", n)
+		}
+		if z != U256(42) {
+			t.Fatalf("%d: UnmarshalBinary modified z", n)
+		}
+	}
+}
+
+func TestMarshalText256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x := randBits256(t)
+
+		b, err := x.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := x.Text(10); string(b) != want {
+			t.Fatalf("#%d: expected %q, got %q", i, want, b)
+		}
+		var z Uint256
+		if err := z.UnmarshalText(b); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if z != x {
+			t.Fatalf("#%d: expected %d, got %d", i, x, z)
+		}
+
+		b, err = HexUint256(x).MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "0x" + x.Text(16); string(b) != want {
+			t.Fatalf("#%d: expected %q, got %q", i, want, b)
+		}
+		var h HexUint256
+		if err := h.UnmarshalText(b); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if Uint256(h) != x {
+			t.Fatalf("#%d: expected %d, got %d", i, x, h)
+		}
+		// Uint256 accepts hexadecimal, too.
+		if err := z.UnmarshalText(b); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if z != x {
+			t.Fatalf("#%d: expected %d, got %d", i, x, z)
+		}
+	}
+
+	var z Uint256
+	for _, s := range []string{"", "-1", "0x", "1.5", max256.Text(10) + "0"} {
+		if err := z.UnmarshalText([]byte(s)); err == nil {
+			t.Fatalf("%q: expected an error", s)
+		}
+	}
+	err := z.UnmarshalText([]byte(max256.Text(10) + "0"))
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Fatalf("expected %v, got %v", strconv.ErrRange, err)
+	}
+}
+
+func TestMarshalJSON256(t *testing.T) {
+	type T struct {
+		S Uint256
+		N NumberUint256
+		H HexUint256
+	}
+	for i := 0; i < 10_000; i++ {
+		x := randBits256(t)
+		in := T{S: x, N: NumberUint256(x), H: HexUint256(x)}
+
+		b, err := json.Marshal(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `{"S":"` + x.Text(10) + `","N":` + x.Text(10) + `,"H":"0x` + x.Text(16) + `"}`
+		if string(b) != want {
+			t.Fatalf("#%d: expected %s, got %s", i, want, b)
+		}
+
+		var out T
+		if err := json.Unmarshal(b, &out); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if out != in {
+			t.Fatalf("#%d: expected %+v, got %+v", i, in, out)
+		}
+	}
+
+	// Both Uint256 and NumberUint256 accept numbers, strings,
+	// and null.
+	for i, tc := range []struct {
+		in   string
+		want Uint256
+	}{
+		{`{"S":123,"N":"456"}`, U256(123)},
+		{`{"S":"0xff","N":"0xff"}`, U256(255)},
+		{`{"S":null,"N":null}`, U256(42)},
+	} {
+		out := T{S: U256(42), N: NumberUint256(U256(42))}
+		if err := json.Unmarshal([]byte(tc.in), &out); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if out.S != tc.want {
+			t.Fatalf("#%d: expected %d, got %d", i, tc.want, out.S)
+		}
+	}
+
+	for i, in := range []string{
+		`{"S":-1}`,
+		`{"S":1.5}`,
+		`{"S":1e3}`,
+		`{"S":"abc"}`,
+		`{"S":true}`,
+		`{"N":` + max256.Text(10) + `0}`,
+	} {
+		var out T
+		if err := json.Unmarshal([]byte(in), &out); err == nil {
+			t.Fatalf("#%d: %s: expected an error", i, in)
+		}
+	}
+}
+
+func TestSQL256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x := randBits256(t)
+
+		v, err := x.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !driver.IsValue(v) {
+			t.Fatalf("#%d: invalid driver.Value: %T", i, v)
+		}
+
+		var n NullUint256
+		if err := n.Scan(v); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if !n.Valid || n.Uint256 != x {
+			t.Fatalf("#%d: expected %d, got %+v", i, x, n)
+		}
+		if err := n.Scan([]byte(v.(string))); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if !n.Valid || n.Uint256 != x {
+			t.Fatalf("#%d: expected %d, got %+v", i, x, n)
+		}
+		if v2, err := n.Value(); err != nil || v2 != v {
+			t.Fatalf("#%d: expected (%v, nil), got (%v, %v)", i, v, v2, err)
+		}
+	}
+
+	n := NullUint256{Uint256: U256(1), Valid: true}
+	if err := n.Scan(int64(42)); err != nil || !n.Valid || n.Uint256 != U256(42) {
+		t.Fatalf("expected 42, got (%+v, %v)", n, err)
+	}
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Fatalf("expected NULL, got (%+v, %v)", n, err)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Fatalf("expected (nil, nil), got (%v, %v)", v, err)
+	}
+	for _, src := range []interface{}{int64(-1), 1.5, true, "abc", []byte("-1")} {
+		if err := n.Scan(src); err == nil {
+			t.Fatalf("%#v: expected an error", src)
+		}
+		if n.Valid {
+			t.Fatalf("%#v: expected Valid == false", src)
+		}
+	}
+}
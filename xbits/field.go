@@ -0,0 +1,338 @@
+package xbits
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Field is the prime field GF(p) for an odd prime p.
+//
+// A Field is safe for concurrent use.
+type Field struct {
+	mont *Montgomery
+	p    Uint256
+	// negOne is -1 in the Montgomery domain.
+	negOne Uint256
+	// legendre is (p-1)/2.
+	legendre Uint256
+	// inv is p-2.
+	inv Uint256
+
+	// If p = 3 mod 4, sqrtExp is (p+1)/4.
+	//
+	// Otherwise, p-1 = q*2^s for odd q, sqrtExp is (q-1)/2,
+	// and sqrtC is c^q in the Montgomery domain for some
+	// non-square c.
+	p3mod4  bool
+	s       int
+	sqrtExp Uint256
+	sqrtC   Uint256
+}
+
+// NewField creates the field GF(p).
+//
+// NewField panics if p is not an odd prime.
+func NewField(p Uint256) *Field {
+	if p.u0&1 == 0 || !p.ProbablyPrime(20) {
+		panic("xbits: NewField: modulus must be an odd prime")
+	}
+	return newField(p)
+}
+
+// newField creates GF(p) for an odd prime p.
+func newField(p Uint256) *Field {
+	f := &Field{
+		mont: NewMontgomery(p),
+		p:    p,
+	}
+	pm1 := p.Sub(U256(1))
+	f.negOne = f.mont.Sub(Uint256{}, f.mont.one)
+	f.legendre = pm1.Rsh(1)
+	f.inv = p.Sub(U256(2))
+
+	if p.u0&3 == 3 {
+		f.p3mod4 = true
+		// (p+1)/4 = (p-3)/4 + 1 avoids overflow.
+		f.sqrtExp = p.Rsh(2).Add(U256(1))
+		return f
+	}
+
+	f.s = pm1.TrailingZeros()
+	q := pm1.Rsh(uint(f.s))
+	f.sqrtExp = q.Rsh(1)
+
+	// Find a non-square by trial. p is public, so this does
+	// not need to be constant time.
+	c := f.mont.one
+	for f.mont.Exp(c, f.legendre) != f.negOne {
+		c = f.mont.Add(c, f.mont.one)
+	}
+	f.sqrtC = f.mont.Exp(c, q)
+	return f
+}
+
+// Modulus returns the modulus p.
+func (f *Field) Modulus() Uint256 {
+	return f.p
+}
+
+// NewElement returns x mod p.
+//
+// This function's execution time does not depend on x.
+func (f *Field) NewElement(x Uint256) Element {
+	return Element{f: f, v: f.mont.ToMont(x)}
+}
+
+// Zero returns 0.
+func (f *Field) Zero() Element {
+	return Element{f: f}
+}
+
+// One returns 1.
+func (f *Field) One() Element {
+	return Element{f: f, v: f.mont.one}
+}
+
+var (
+	p256Once, p256ScalarOnce           sync.Once
+	secp256k1Once, secp256k1ScalarOnce sync.Once
+
+	p256, p256Scalar           *Field
+	secp256k1, secp256k1Scalar *Field
+)
+
+// P256 returns the base field of the NIST P-256 curve.
+func P256() *Field {
+	p256Once.Do(func() {
+		// 2^256 - 2^224 + 2^192 + 2^96 - 1
+		p256 = newField(Uint256{
+			0xffffffffffffffff, 0x00000000ffffffff,
+			0x0000000000000000, 0xffffffff00000001,
+		})
+	})
+	return p256
+}
+
+// P256Scalar returns the scalar field of the NIST P-256
+// curve, whose modulus is the order of the base point.
+func P256Scalar() *Field {
+	p256ScalarOnce.Do(func() {
+		p256Scalar = newField(Uint256{
+			0xf3b9cac2fc632551, 0xbce6faada7179e84,
+			0xffffffffffffffff, 0xffffffff00000000,
+		})
+	})
+	return p256Scalar
+}
+
+// Secp256k1 returns the base field of the secp256k1 curve.
+func Secp256k1() *Field {
+	secp256k1Once.Do(func() {
+		// 2^256 - 2^32 - 977
+		secp256k1 = newField(Uint256{
+			0xfffffffefffffc2f, 0xffffffffffffffff,
+			0xffffffffffffffff, 0xffffffffffffffff,
+		})
+	})
+	return secp256k1
+}
+
+// Secp256k1Scalar returns the scalar field of the secp256k1
+// curve, whose modulus is the order of the base point.
+func Secp256k1Scalar() *Field {
+	secp256k1ScalarOnce.Do(func() {
+		secp256k1Scalar = newField(Uint256{
+			0xbfd25e8cd0364141, 0xbaaedce6af48a03b,
+			0xfffffffffffffffe, 0xffffffffffffffff,
+		})
+	})
+	return secp256k1Scalar
+}
+
+// Element is an element of a Field.
+//
+// The zero value is not a valid Element. Use the methods
+// on Field to create Elements.
+//
+// Unless otherwise noted, the operands of each method must
+// belong to the same Field.
+type Element struct {
+	f *Field
+	// v is the value in the Montgomery domain.
+	v Uint256
+}
+
+var (
+	_ fmt.Stringer  = Element{}
+	_ fmt.Formatter = Element{}
+)
+
+// check panics if x and y belong to different fields.
+func (x Element) check(y Element) {
+	if x.f != y.f && (x.f == nil || y.f == nil || x.f.p != y.f.p) {
+		panic("xbits: mismatched fields")
+	}
+}
+
+// with returns v as an Element of x's field.
+func (x Element) with(v Uint256) Element {
+	return Element{f: x.f, v: v}
+}
+
+// Field returns the field that x belongs to.
+func (x Element) Field() *Field {
+	return x.f
+}
+
+// Uint256 returns x as an integer in [0, p).
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x Element) Uint256() Uint256 {
+	return x.f.mont.FromMont(x.v)
+}
+
+// Add returns x + y.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x Element) Add(y Element) Element {
+	x.check(y)
+	return x.with(x.f.mont.Add(x.v, y.v))
+}
+
+// Sub returns x - y.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x Element) Sub(y Element) Element {
+	x.check(y)
+	return x.with(x.f.mont.Sub(x.v, y.v))
+}
+
+// Neg returns -x.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x Element) Neg() Element {
+	return x.with(x.f.mont.Sub(Uint256{}, x.v))
+}
+
+// Mul returns x * y.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x Element) Mul(y Element) Element {
+	x.check(y)
+	return x.with(x.f.mont.Mul(x.v, y.v))
+}
+
+// Square returns x^2.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x Element) Square() Element {
+	return x.with(x.f.mont.Sqr(x.v))
+}
+
+// Exp returns x^y.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x Element) Exp(y Uint256) Element {
+	return x.with(x.f.mont.Exp(x.v, y))
+}
+
+// Inv returns x^-1.
+//
+// The inverse of 0 is 0.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x Element) Inv() Element {
+	// Fermat's little theorem: x^(p-2) = x^-1.
+	return x.Exp(x.f.inv)
+}
+
+// Equal returns 1 if x == y and 0 otherwise.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x Element) Equal(y Element) uint64 {
+	x.check(y)
+	return eq256(x.v, y.v)
+}
+
+// IsZero returns 1 if x == 0 and 0 otherwise.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x Element) IsZero() uint64 {
+	return x.v.IsZero()
+}
+
+// Legendre returns the Legendre symbol of x, which is
+//
+//	+1 if x is a non-zero square
+//	 0 if x == 0
+//	-1 if x is not a square
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x Element) Legendre() int {
+	r := x.f.mont.Exp(x.v, x.f.legendre)
+	return int(eq256(r, x.f.mont.one)) - int(eq256(r, x.f.negOne))
+}
+
+// IsSquare returns 1 if x is a square, including 0, and 0
+// otherwise.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x Element) IsSquare() uint64 {
+	r := x.f.mont.Exp(x.v, x.f.legendre)
+	return eq256(r, x.f.negOne) ^ 1
+}
+
+// Sqrt returns a square root of x and 1 if x is a square,
+// or 0 and 0 otherwise.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x Element) Sqrt() (Element, uint64) {
+	f := x.f
+	m := f.mont
+
+	var z Uint256
+	if f.p3mod4 {
+		z = m.Exp(x.v, f.sqrtExp)
+	} else {
+		// Constant-time Tonelli-Shanks from RFC 9380,
+		// appendix I.4.
+		z = m.Exp(x.v, f.sqrtExp)
+		t := m.Mul(m.Sqr(z), x.v)
+		z = m.Mul(z, x.v)
+		b := t
+		c := f.sqrtC
+		for i := f.s; i >= 2; i-- {
+			for j := 1; j <= i-2; j++ {
+				b = m.Sqr(b)
+			}
+			e := eq256(b, m.one)
+			z = Select256(e, z, m.Mul(z, c))
+			c = m.Sqr(c)
+			t = Select256(e, t, m.Mul(t, c))
+			b = t
+		}
+	}
+	ok := eq256(m.Sqr(z), x.v)
+	return x.with(Select256(ok, z, Uint256{})), ok
+}
+
+func (x Element) String() string {
+	return x.Uint256().String()
+}
+
+func (x Element) Format(s fmt.State, ch rune) {
+	x.Uint256().Format(s, ch)
+}
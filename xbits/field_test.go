@@ -0,0 +1,227 @@
+package xbits
+
+import (
+	"math/big"
+	"testing"
+)
+
+// testFields returns the built-in fields, which cover both
+// p = 3 mod 4 and p = 1 mod 4, and a small field with a
+// large 2-adicity.
+func testFields() []*Field {
+	return []*Field{
+		P256(),
+		P256Scalar(),
+		Secp256k1(),
+		Secp256k1Scalar(),
+		// 2^64 - 2^32 + 1, with 2-adicity 32.
+		NewField(U256(0xffffffff00000001)),
+		NewField(U256(3)),
+	}
+}
+
+func randElement(t testing.TB, f *Field) Element {
+	return f.NewElement(randBits256(t))
+}
+
+func TestFieldConstants(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		f    *Field
+		p    string
+	}{
+		{"P256", P256(), "ffffffff00000001000000000000000000000000ffffffffffffffffffffffff"},
+		{"P256Scalar", P256Scalar(), "ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551"},
+		{"Secp256k1", Secp256k1(), "fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"},
+		{"Secp256k1Scalar", Secp256k1Scalar(), "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"},
+	} {
+		if got := tc.f.Modulus().Text(16); got != tc.p {
+			t.Fatalf("%s: expected %s, got %s", tc.name, tc.p, got)
+		}
+		var bp big.Int
+		if !tc.f.Modulus().ToBig(&bp).ProbablyPrime(20) {
+			t.Fatalf("%s: modulus is not prime", tc.name)
+		}
+	}
+}
+
+func TestFieldArith(t *testing.T) {
+	for _, f := range testFields() {
+		var bp big.Int
+		f.Modulus().ToBig(&bp)
+		for i := 0; i < 1_000; i++ {
+			x, y := randElement(t, f), randElement(t, f)
+			e := randBits256(t)
+
+			var bx, by, be big.Int
+			x.Uint256().ToBig(&bx)
+			y.Uint256().ToBig(&by)
+			e.ToBig(&be)
+
+			for _, tc := range []struct {
+				name string
+				got  Element
+				want *big.Int
+			}{
+				{"Add", x.Add(y), new(big.Int).Add(&bx, &by)},
+				{"Sub", x.Sub(y), new(big.Int).Sub(&bx, &by)},
+				{"Neg", x.Neg(), new(big.Int).Neg(&bx)},
+				{"Mul", x.Mul(y), new(big.Int).Mul(&bx, &by)},
+				{"Square", x.Square(), new(big.Int).Mul(&bx, &bx)},
+				{"Exp", x.Exp(e), new(big.Int).Exp(&bx, &be, &bp)},
+				{"Inv", x.Inv(), new(big.Int).ModInverse(&bx, &bp)},
+			} {
+				if tc.want == nil {
+					// ModInverse(0) is nil; Inv(0) is 0.
+					tc.want = new(big.Int)
+				}
+				tc.want.Mod(tc.want, &bp)
+				if cmpInt(tc.want, tc.got.Uint256()) != 0 {
+					t.Fatalf("%d: #%d: %s(%d, %d): expected %s, got %d",
+						f.Modulus(), i, tc.name, x, y, tc.want, tc.got)
+				}
+			}
+
+			if got, want := x.Equal(y) == 1, bx.Cmp(&by) == 0; got != want {
+				t.Fatalf("#%d: Equal(%d, %d): expected %t, got %t", i, x, y, want, got)
+			}
+			if x.Equal(x) != 1 {
+				t.Fatalf("#%d: Equal(%d, %d): expected true", i, x, x)
+			}
+			if got, want := x.IsZero() == 1, bx.Sign() == 0; got != want {
+				t.Fatalf("#%d: IsZero(%d): expected %t, got %t", i, x, want, got)
+			}
+		}
+	}
+}
+
+func TestFieldSqrt(t *testing.T) {
+	for _, f := range testFields() {
+		var bp big.Int
+		f.Modulus().ToBig(&bp)
+		for i := 0; i < 1_000; i++ {
+			x := randElement(t, f)
+			if i%2 == 0 {
+				x = x.Square()
+			}
+			if i == 1 {
+				x = f.Zero()
+			}
+
+			var bx big.Int
+			x.Uint256().ToBig(&bx)
+			want := big.Jacobi(&bx, &bp)
+
+			if got := x.Legendre(); got != want {
+				t.Fatalf("%d: #%d: Legendre(%d): expected %d, got %d", f.Modulus(), i, x, want, got)
+			}
+			if got := x.IsSquare() == 1; got != (want >= 0) {
+				t.Fatalf("%d: #%d: IsSquare(%d): expected %t, got %t", f.Modulus(), i, x, want >= 0, got)
+			}
+			z, ok := x.Sqrt()
+			if ok != 1 {
+				if want >= 0 {
+					t.Fatalf("%d: #%d: Sqrt(%d): expected a square root", f.Modulus(), i, x)
+				}
+				if z.IsZero() != 1 {
+					t.Fatalf("%d: #%d: Sqrt(%d): expected 0, got %d", f.Modulus(), i, x, z)
+				}
+				continue
+			}
+			if want < 0 {
+				t.Fatalf("%d: #%d: Sqrt(%d): unexpected square root %d", f.Modulus(), i, x, z)
+			}
+			if z.Square().Equal(x) != 1 {
+				t.Fatalf("%d: #%d: Sqrt(%d): %d^2 != %d", f.Modulus(), i, x, z, x)
+			}
+		}
+	}
+}
+
+func TestFieldElement(t *testing.T) {
+	f := P256()
+	x := f.NewElement(max256)
+	var want big.Int
+	want.Mod(max256.ToBig(new(big.Int)), f.Modulus().ToBig(new(big.Int)))
+	if cmpInt(&want, x.Uint256()) != 0 {
+		t.Fatalf("expected %s, got %d", &want, x)
+	}
+	if x.Field() != f {
+		t.Fatal("Field returned the wrong field")
+	}
+	if got := f.One().Uint256(); got != U256(1) {
+		t.Fatalf("One: expected 1, got %d", got)
+	}
+	if got := f.Zero().Uint256(); got != U256(0) {
+		t.Fatalf("Zero: expected 0, got %d", got)
+	}
+	if got := f.NewElement(f.Modulus()).IsZero(); got != 1 {
+		t.Fatal("p mod p != 0")
+	}
+	// Elements from separate instances of the same field
+	// can be mixed.
+	g := NewField(f.Modulus())
+	if x.Add(g.One()) != x.Add(f.One()) {
+		t.Fatal("mixing equal fields failed")
+	}
+}
+
+func TestFieldPanic(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		fn   func()
+		msg  string
+	}{
+		{"even", func() { NewField(U256(4)) }, "xbits: NewField: modulus must be an odd prime"},
+		{"composite", func() { NewField(U256(15)) }, "xbits: NewField: modulus must be an odd prime"},
+		{"mismatch", func() { P256().One().Add(Secp256k1().One()) }, "xbits: mismatched fields"},
+	} {
+		func() {
+			defer func() {
+				if got := recover(); got != tc.msg {
+					t.Fatalf("%s: expected panic %q, got %v", tc.name, tc.msg, got)
+				}
+			}()
+			tc.fn()
+		}()
+	}
+}
+
+func BenchmarkFieldMul(b *testing.B) {
+	f := P256()
+	x, y := randElement(b, f), randElement(b, f)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x = x.Mul(y)
+	}
+	Sink256 = x.v
+}
+
+func BenchmarkFieldInv(b *testing.B) {
+	f := P256()
+	x := randElement(b, f)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x = x.Inv()
+	}
+	Sink256 = x.v
+}
+
+func BenchmarkFieldSqrt(b *testing.B) {
+	for _, tc := range []struct {
+		name string
+		f    *Field
+	}{
+		{"P256", P256()},
+		{"P256Scalar", P256Scalar()},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			x := randElement(b, tc.f).Square()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				z, _ := x.Sqrt()
+				Sink256 = z.v
+			}
+		})
+	}
+}
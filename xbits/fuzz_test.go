@@ -0,0 +1,387 @@
+//go:build go1.18
+// +build go1.18
+
+package xbits
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// The fuzz targets differentially test Uint256 against
+// math/big. The seed corpus in testdata/fuzz covers the
+// known edge cases; to search for more, run, for example,
+//
+//	go test -run XXX -fuzz FuzzQuoRem
+
+// fuzzUint256 interprets the first 32 bytes of b as
+// a big-endian integer.
+func fuzzUint256(b []byte) Uint256 {
+	if len(b) > 32 {
+		b = b[:32]
+	}
+	var z Uint256
+	z.SetBytes(b)
+	return z
+}
+
+// checkBig fails the test if got != want.
+//
+// format and args describe the operation.
+func checkBig(t *testing.T, want *big.Int, got Uint256, format string, args ...interface{}) {
+	t.Helper()
+	if cmpInt(want, got) != 0 {
+		t.Fatalf("%s: expected %s, got %d", fmt.Sprintf(format, args...), want, got)
+	}
+}
+
+// checkPanic fails the test if fn does not panic.
+func checkPanic(t *testing.T, name string, fn func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("%s: expected a panic", name)
+		}
+	}()
+	fn()
+}
+
+func FuzzQuoRem(f *testing.F) {
+	f.Fuzz(func(t *testing.T, xb, yb []byte) {
+		x, y := fuzzUint256(xb), fuzzUint256(yb)
+		if y.IsZero() == 1 {
+			checkPanic(t, "QuoRem", func() { x.QuoRem(y) })
+			checkPanic(t, "QuoRemCT", func() { x.QuoRemCT(y) })
+			checkPanic(t, "NewDivisor", func() { NewDivisor(y) })
+			return
+		}
+
+		var bx, by, bq, br big.Int
+		x.ToBig(&bx)
+		y.ToBig(&by)
+		bq.QuoRem(&bx, &by, &br)
+
+		q, r := x.QuoRem(y)
+		checkBig(t, &bq, q, "QuoRem(%d, %d)", x, y)
+		checkBig(t, &br, r, "QuoRem(%d, %d)", x, y)
+		checkBig(t, &bq, x.Quo(y), "Quo(%d, %d)", x, y)
+		checkBig(t, &br, x.Rem(y), "Rem(%d, %d)", x, y)
+		checkBig(t, &br, x.ModCT(y), "ModCT(%d, %d)", x, y)
+
+		q, r = x.QuoRemCT(y)
+		checkBig(t, &bq, q, "QuoRemCT(%d, %d)", x, y)
+		checkBig(t, &br, r, "QuoRemCT(%d, %d)", x, y)
+
+		d := NewDivisor(y)
+		q, r = d.QuoRem(x)
+		checkBig(t, &bq, q, "Divisor(%d).QuoRem(%d)", y, x)
+		checkBig(t, &br, r, "Divisor(%d).QuoRem(%d)", y, x)
+
+		// Check 512-bit dividends, too.
+		xx := x.MulFull(x.Xor(max256))
+		var bxx big.Int
+		setInt512(&bxx, xx)
+		br.Mod(&bxx, &by)
+		checkBig(t, &br, d.Mod512(xx), "Divisor(%d).Mod512(%d)", y, xx)
+		checkBig(t, &br, NewBarrett(y).Reduce512(xx), "Barrett(%d).Reduce512(%d)", y, xx)
+	})
+}
+
+func FuzzMulMod(f *testing.F) {
+	f.Fuzz(func(t *testing.T, xb, yb, mb []byte) {
+		x, y, m := fuzzUint256(xb), fuzzUint256(yb), fuzzUint256(mb)
+
+		var bx, by, bm, bz big.Int
+		x.ToBig(&bx)
+		y.ToBig(&by)
+		m.ToBig(&bm)
+
+		bz.Add(&bx, &by)
+		fits := bz.BitLen() <= 256
+		bz.And(&bz, big256Mask)
+		checkBig(t, &bz, x.Add(y), "Add(%d, %d)", x, y)
+		if z, ok := x.AddChecked(y); ok != fits || cmpInt(&bz, z) != 0 {
+			t.Fatalf("AddChecked(%d, %d): expected (%s, %t), got (%d, %t)", x, y, &bz, fits, z, ok)
+		}
+		if !fits {
+			bz.Set(big256Mask)
+		}
+		checkBig(t, &bz, x.AddSat(y), "AddSat(%d, %d)", x, y)
+
+		bz.Sub(&bx, &by)
+		fits = bz.Sign() >= 0
+		bz.And(&bz, big256Mask)
+		checkBig(t, &bz, x.Sub(y), "Sub(%d, %d)", x, y)
+		if z, ok := x.SubChecked(y); ok != fits || cmpInt(&bz, z) != 0 {
+			t.Fatalf("SubChecked(%d, %d): expected (%s, %t), got (%d, %t)", x, y, &bz, fits, z, ok)
+		}
+		if !fits {
+			bz.SetUint64(0)
+		}
+		checkBig(t, &bz, x.SubSat(y), "SubSat(%d, %d)", x, y)
+
+		bz.Mul(&bx, &by)
+		if got := x.MulFull(y); cmpInt512(&bz, got) != 0 {
+			t.Fatalf("MulFull(%d, %d): expected %s, got %d", x, y, &bz, got)
+		}
+		fits = bz.BitLen() <= 256
+		bz.And(&bz, big256Mask)
+		checkBig(t, &bz, x.Mul(y), "Mul(%d, %d)", x, y)
+		if z, ok := x.MulChecked(y); ok != fits || cmpInt(&bz, z) != 0 {
+			t.Fatalf("MulChecked(%d, %d): expected (%s, %t), got (%d, %t)", x, y, &bz, fits, z, ok)
+		}
+		if !fits {
+			bz.Set(big256Mask)
+		}
+		checkBig(t, &bz, x.MulSat(y), "MulSat(%d, %d)", x, y)
+
+		bz.Mul(&bx, &bx)
+		if got := x.SqrFull(); cmpInt512(&bz, got) != 0 {
+			t.Fatalf("SqrFull(%d): expected %s, got %d", x, &bz, got)
+		}
+		bz.And(&bz, big256Mask)
+		checkBig(t, &bz, x.Sqr(), "Sqr(%d)", x)
+
+		checkBig(t, bz.And(&bx, &by), x.And(y), "And(%d, %d)", x, y)
+		checkBig(t, bz.Or(&bx, &by), x.Or(y), "Or(%d, %d)", x, y)
+		checkBig(t, bz.Xor(&bx, &by), x.Xor(y), "Xor(%d, %d)", x, y)
+
+		c := bx.Cmp(&by)
+		if got := x.Cmp(y); got != c {
+			t.Fatalf("Cmp(%d, %d): expected %d, got %d", x, y, c, got)
+		}
+		if lt, eq := x.CmpCT(y); (lt == 1) != (c < 0) || (eq == 1) != (c == 0) || lt&eq != 0 {
+			t.Fatalf("CmpCT(%d, %d): expected %d, got (%d, %d)", x, y, c, lt, eq)
+		}
+		if got := x.Lt(y); (got == 1) != (c < 0) {
+			t.Fatalf("Lt(%d, %d): expected %t, got %d", x, y, c < 0, got)
+		}
+		if got := x.Eq(y); (got == 1) != (c == 0) {
+			t.Fatalf("Eq(%d, %d): expected %t, got %d", x, y, c == 0, got)
+		}
+
+		if m.IsZero() == 1 {
+			checkPanic(t, "MulMod", func() { x.MulMod(y, m) })
+			checkPanic(t, "MulModCT", func() { x.MulModCT(y, m) })
+			return
+		}
+		bz.Mul(&bx, &by)
+		bz.Mod(&bz, &bm)
+		checkBig(t, &bz, x.MulMod(y, m), "MulMod(%d, %d, %d)", x, y, m)
+		checkBig(t, &bz, x.MulModCT(y, m), "MulModCT(%d, %d, %d)", x, y, m)
+		checkBig(t, &bz, NewBarrett(m).MulMod(x, y), "Barrett(%d).MulMod(%d, %d)", m, x, y)
+		if m.u0&1 == 1 {
+			z := NewMontgomery(m)
+			got := z.FromMont(z.Mul(z.ToMont(x), z.ToMont(y)))
+			checkBig(t, &bz, got, "Montgomery(%d).Mul(%d, %d)", m, x, y)
+		}
+
+		bz.Mul(&bx, &bx)
+		bz.Mod(&bz, &bm)
+		checkBig(t, &bz, NewBarrett(m).SqrMod(x), "Barrett(%d).SqrMod(%d)", m, x)
+		if m.u0&1 == 1 {
+			z := NewMontgomery(m)
+			got := z.FromMont(z.Sqr(z.ToMont(x)))
+			checkBig(t, &bz, got, "Montgomery(%d).Sqr(%d)", m, x)
+		}
+	})
+}
+
+func FuzzExp(f *testing.F) {
+	f.Fuzz(func(t *testing.T, xb, yb, mb []byte) {
+		x, y, m := fuzzUint256(xb), fuzzUint256(yb), fuzzUint256(mb)
+		if m.IsZero() == 1 {
+			checkPanic(t, "ExpCT", func() { x.ExpCT(y, m) })
+			return
+		}
+
+		var bx, by, bm, bz big.Int
+		x.ToBig(&bx)
+		y.ToBig(&by)
+		m.ToBig(&bm)
+		bz.Exp(&bx, &by, &bm)
+
+		checkBig(t, &bz, x.Exp(y, m), "Exp(%d, %d, %d)", x, y, m)
+		checkBig(t, &bz, x.ExpCT(y, m), "ExpCT(%d, %d, %d)", x, y, m)
+		checkBig(t, &bz, NewBarrett(m).ExpMod(x, y), "Barrett(%d).ExpMod(%d, %d)", m, x, y)
+
+		// Also check x^-1 mod m. Every x is invertible mod 1
+		// according to math/big, so skip that case.
+		if m == U256(1) {
+			return
+		}
+		nx, nm := Nat256(x), Nat256(m)
+		var z Nat256
+		inv := z.ModInverse(&nx, &nm)
+		if bz.ModInverse(&bx, &bm) == nil {
+			if inv != nil {
+				t.Fatalf("ModInverse(%d, %d): expected no inverse, got %d", x, m, Uint256(*inv))
+			}
+			return
+		}
+		if inv == nil {
+			t.Fatalf("ModInverse(%d, %d): expected %s, got no inverse", x, m, &bz)
+		}
+		checkBig(t, &bz, Uint256(*inv), "ModInverse(%d, %d)", x, m)
+		if m.u0&1 == 1 {
+			got, ok := x.ModInverseCT(m)
+			if ok != 1 {
+				t.Fatalf("ModInverseCT(%d, %d): expected an inverse", x, m)
+			}
+			checkBig(t, &bz, got, "ModInverseCT(%d, %d)", x, m)
+		}
+	})
+}
+
+func FuzzShift(f *testing.F) {
+	f.Fuzz(func(t *testing.T, xb []byte, n uint16) {
+		x := fuzzUint256(xb)
+		s := uint(n % 320)
+
+		var bx, bz, bt big.Int
+		x.ToBig(&bx)
+
+		bz.Lsh(&bx, s)
+		bz.And(&bz, big256Mask)
+		checkBig(t, &bz, x.Lsh(s), "Lsh(%d, %d)", x, s)
+		checkBig(t, bz.Rsh(&bx, s), x.Rsh(s), "Rsh(%d, %d)", x, s)
+
+		k := s % 256
+		bz.Lsh(&bx, k)
+		bz.Or(&bz, bt.Rsh(&bx, 256-k))
+		bz.And(&bz, big256Mask)
+		checkBig(t, &bz, x.RotateLeft(int(s)), "RotateLeft(%d, %d)", x, s)
+		checkBig(t, &bz, x.RotateRight(-int(s)), "RotateRight(%d, %d)", x, -int(s))
+
+		if got, want := x.Bit(int(s)), bx.Bit(int(s)); got != want {
+			t.Fatalf("Bit(%d, %d): expected %d, got %d", x, s, want, got)
+		}
+		if got, want := x.BitLen(), bx.BitLen(); got != want {
+			t.Fatalf("BitLen(%d): expected %d, got %d", x, want, got)
+		}
+		if got, want := x.LeadingZeros(), 256-bx.BitLen(); got != want {
+			t.Fatalf("LeadingZeros(%d): expected %d, got %d", x, want, got)
+		}
+		want := int(bx.TrailingZeroBits())
+		if bx.Sign() == 0 {
+			want = 256
+		}
+		if got := x.TrailingZeros(); got != want {
+			t.Fatalf("TrailingZeros(%d): expected %d, got %d", x, want, got)
+		}
+		want = strings.Count(bx.Text(2), "1")
+		if got := x.OnesCount(); got != want {
+			t.Fatalf("OnesCount(%d): expected %d, got %d", x, want, got)
+		}
+
+		// Reverse the bits and bytes using big-endian
+		// strings.
+		bin := []byte(fmt.Sprintf("%0256b", &bx))
+		for i, j := 0, len(bin)-1; i < j; i, j = i+1, j-1 {
+			bin[i], bin[j] = bin[j], bin[i]
+		}
+		bz.SetString(string(bin), 2)
+		checkBig(t, &bz, x.Reverse(), "Reverse(%d)", x)
+		buf := bx.FillBytes(make([]byte, 32))
+		for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+			buf[i], buf[j] = buf[j], buf[i]
+		}
+		bz.SetBytes(buf)
+		checkBig(t, &bz, x.ReverseBytes(), "ReverseBytes(%d)", x)
+	})
+}
+
+// fuzzFormats are the formats checked by FuzzFormat.
+var fuzzFormats = []string{
+	"%b", "%o", "%O", "%d", "%x", "%X", "%s", "%v",
+	"%#b", "%#o", "%#x", "%#X", "%+d", "% d",
+	"%080d", "%-80x|", "%80X", "%.50d", "%#.70x", "%.0d",
+}
+
+func FuzzFormat(f *testing.F) {
+	f.Fuzz(func(t *testing.T, xb []byte, base uint8, s string) {
+		x := fuzzUint256(xb)
+		var bx big.Int
+		x.ToBig(&bx)
+
+		b := 2 + int(base)%61
+		want := bx.Text(b)
+		if got := x.Text(b); got != want {
+			t.Fatalf("Text(%d, %d): expected %q, got %q", x, b, want, got)
+		}
+		if got := string(x.AppendText([]byte("x"), b)); got != "x"+want {
+			t.Fatalf("AppendText(%d, %d): expected %q, got %q", x, b, "x"+want, got)
+		}
+		if z, err := ParseUint256(want, b); err != nil || z != x {
+			t.Fatalf("ParseUint256(%q, %d): expected %d, got (%d, %v)", want, b, x, z, err)
+		}
+
+		format := fuzzFormats[int(base)%len(fuzzFormats)]
+		if got, want := fmt.Sprintf(format, x), fmt.Sprintf(format, &bx); got != want {
+			t.Fatalf("Sprintf(%q, %d): expected %q, got %q", format, x, want, got)
+		}
+		if got, want := x.String(), bx.String(); got != want {
+			t.Fatalf("String(%d): expected %q, got %q", x, want, got)
+		}
+
+		text, err := x.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var z Uint256
+		if err := z.UnmarshalText(text); err != nil || z != x {
+			t.Fatalf("UnmarshalText(%q): expected %d, got (%d, %v)", text, x, z, err)
+		}
+		js, err := json.Marshal(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		z = Uint256{}
+		if err := json.Unmarshal(js, &z); err != nil || z != x {
+			t.Fatalf("json.Unmarshal(%q): expected %d, got (%d, %v)", js, x, z, err)
+		}
+
+		// Every string that ParseUint256 accepts must be a
+		// valid integer literal that fits in 256 bits.
+		z, err = ParseUint256(s, 0)
+		if err != nil {
+			return
+		}
+		var bs big.Int
+		if _, ok := bs.SetString(s, 0); !ok {
+			t.Fatalf("ParseUint256(%q, 0): expected an error, got %d", s, z)
+		}
+		checkBig(t, &bs, z, "ParseUint256(%q, 0)", s)
+	})
+}
+
+func FuzzNumTheory(f *testing.F) {
+	f.Fuzz(func(t *testing.T, xb, yb []byte) {
+		x, y := fuzzUint256(xb), fuzzUint256(yb)
+
+		var bx, by, bz big.Int
+		x.ToBig(&bx)
+		y.ToBig(&by)
+
+		checkBig(t, bz.Sqrt(&bx), x.Sqrt(), "Sqrt(%d)", x)
+		checkBig(t, bz.GCD(nil, nil, &bx, &by), x.GCD(y), "GCD(%d, %d)", x, y)
+
+		g, a, b := x.ExtendedGCD(y)
+		checkBig(t, &bz, g, "ExtendedGCD(%d, %d)", x, y)
+		var ba, bb big.Int
+		a.ToBig(&ba)
+		b.ToBig(&bb)
+		ba.Mul(&ba, &bx)
+		bb.Mul(&bb, &by)
+		if ba.Add(&ba, &bb).Cmp(&bz) != 0 {
+			t.Fatalf("ExtendedGCD(%d, %d): %d*x + %d*y != %d", x, y, a, b, g)
+		}
+
+		if got, want := x.ProbablyPrime(0), bx.ProbablyPrime(0); got != want {
+			t.Fatalf("ProbablyPrime(%d): expected %t, got %t", x, want, got)
+		}
+	})
+}
@@ -0,0 +1,1948 @@
+//go:build ignore
+// +build ignore
+
+// gen_uint generates the fixed-width unsigned integer types
+// UintN and their tests.
+//
+// Usage:
+//
+//	go run gen_uint.go [-o dir] [widths...]
+//
+// Each width must be a multiple of 64 in [128, 1024] other
+// than 256, which is written by hand. With no widths,
+// gen_uint generates 128, 384, 448, and 512.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+func main() {
+	log.SetPrefix("gen_uint: ")
+	log.SetFlags(0)
+
+	dir := flag.String("o", ".", "output directory")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		args = []string{"128", "384", "448", "512"}
+	}
+	var widths []int
+	// known is the set of widths that have a type in the
+	// package. Uint256 is written by hand.
+	known := map[int]bool{256: true}
+	for _, s := range args {
+		n, err := strconv.Atoi(s)
+		if err != nil || n%64 != 0 || n < 128 || n > 1024 || n == 256 {
+			log.Fatalf("invalid width: %q", s)
+		}
+		widths = append(widths, n)
+		known[n] = true
+	}
+	for _, n := range widths {
+		t := newType(n, known)
+		gen(filepath.Join(*dir, fmt.Sprintf("uint%d.go", n)), srcTmpl, t)
+		gen(filepath.Join(*dir, fmt.Sprintf("uint%d_test.go", n)), testTmpl, t)
+	}
+}
+
+// Type describes a generated type.
+type Type struct {
+	Bits  int // width in bits
+	Bytes int // width in bytes
+	Words int // width in 64-bit words
+
+	// Half is the type with half as many bits, or nil if
+	// there is no such type.
+	Half *Type
+	// Wide is the type with twice as many bits, or nil if
+	// there is no such type.
+	Wide *Type
+}
+
+func newType(bits int, known map[int]bool) Type {
+	t := Type{Bits: bits, Bytes: bits / 8, Words: bits / 64}
+	if known[bits/2] {
+		h := newType(bits/2, nil)
+		t.Half = &h
+	}
+	if known[bits*2] {
+		w := newType(bits*2, nil)
+		t.Wide = &w
+	}
+	return t
+}
+
+// DoubleBits returns twice the width in bits.
+func (t Type) DoubleBits() int { return 2 * t.Bits }
+
+// DoubleWords returns twice the width in words.
+func (t Type) DoubleWords() int { return 2 * t.Words }
+
+// Name returns the type name, like Uint384.
+func (t Type) Name() string { return fmt.Sprintf("Uint%d", t.Bits) }
+
+// Fields returns the struct fields, like "u0, u1, u2".
+func (t Type) Fields() string { return t.Sel("") }
+
+// Sel returns the struct fields of v, like "x.u0, x.u1".
+func (t Type) Sel(v string) string {
+	var b strings.Builder
+	for i := 0; i < t.Words; i++ {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if v != "" {
+			b.WriteString(v + ".")
+		}
+		fmt.Fprintf(&b, "u%d", i)
+	}
+	return b.String()
+}
+
+// Index returns the elements of the array v, like
+// "w[0], w[1], w[2]".
+func (t Type) Index(v string) string {
+	var b strings.Builder
+	for i := 0; i < t.Words; i++ {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s[%d]", v, i)
+	}
+	return b.String()
+}
+
+func gen(path string, tmpl *template.Template, t Type) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, t); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("%s: %v\n%s", path, err, buf.Bytes())
+	}
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var srcTmpl = template.Must(template.New("src").Parse(`// Code generated by gen_uint.go. DO NOT EDIT.
+
+package xbits
+
+import (
+	"crypto/subtle"
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/elagergren/ctb/xbits/ct"
+)
+
+// {{.Name}} is an unsigned {{.Bits}}-bit integer.
+type {{.Name}} struct {
+	{{.Fields}} uint64
+}
+
+var (
+	_ fmt.Stringer  = {{.Name}}{}
+	_ fmt.Formatter = {{.Name}}{}
+	_ fmt.Scanner   = (*{{.Name}})(nil)
+
+	_ encoding.BinaryMarshaler   = {{.Name}}{}
+	_ encoding.BinaryUnmarshaler = (*{{.Name}})(nil)
+)
+
+// U{{.Bits}} creates a {{.Name}} from a uint64.
+func U{{.Bits}}(x uint64) {{.Name}} {
+	return {{.Name}}{u0: x}
+}
+
+// u{{.Bits}} creates a {{.Name}} from little-endian words.
+func u{{.Bits}}(w [{{.Words}}]uint64) {{.Name}} {
+	return {{.Name}}{ {{.Index "w"}} }
+}
+
+// words returns x as little-endian words.
+func (x {{.Name}}) words() [{{.Words}}]uint64 {
+	return [{{.Words}}]uint64{ {{.Sel "x"}} }
+}
+
+// FromBig{{.Bits}} converts b to a {{.Name}} and reports whether b
+// can be represented as a {{.Name}}.
+//
+// If b is negative or b > 1<<{{.Bits}}-1, FromBig{{.Bits}} returns b
+// modulo 2^{{.Bits}} and false.
+func FromBig{{.Bits}}(b *big.Int) ({{.Name}}, bool) {
+	var w [{{.Words}}]uint64
+	ok := bigWords(w[:], b)
+	x := u{{.Bits}}(w)
+	if b.Sign() < 0 {
+		return {{.Name}}{}.Sub(x), false
+	}
+	return x, ok
+}
+
+// Parse{{.Name}} returns the value of s in the given base.
+//
+// See ParseUint256 for the accepted input and the errors
+// that Parse{{.Name}} returns.
+func Parse{{.Name}}(s string, base int) ({{.Name}}, error) {
+	var w [{{.Words}}]uint64
+	err := parseWords(w[:], s, base, "Parse{{.Name}}")
+	return u{{.Bits}}(w), err
+}
+
+// Add returns x + y.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) Add(y {{.Name}}) {{.Name}} {
+	xw, yw := x.words(), y.words()
+	addVV(xw[:], xw[:], yw[:])
+	return u{{.Bits}}(xw)
+}
+
+// AddChecked returns x + y and reports whether
+// the sum did not overflow.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) AddChecked(y {{.Name}}) ({{.Name}}, bool) {
+	xw, yw := x.words(), y.words()
+	c := addVV(xw[:], xw[:], yw[:])
+	return u{{.Bits}}(xw), c == 0
+}
+
+// AddSat returns x + y, saturating at 1<<{{.Bits}}-1 instead
+// of overflowing.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) AddSat(y {{.Name}}) {{.Name}} {
+	xw, yw := x.words(), y.words()
+	c := addVV(xw[:], xw[:], yw[:])
+	for i := range xw {
+		xw[i] |= -c
+	}
+	return u{{.Bits}}(xw)
+}
+
+// And returns x & y.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) And(y {{.Name}}) {{.Name}} {
+	xw, yw := x.words(), y.words()
+	for i := range xw {
+		xw[i] &= yw[i]
+	}
+	return u{{.Bits}}(xw)
+}
+
+// AppendText appends the textual representation of x in
+// the provided base to dst and returns the extended
+// buffer.
+//
+// AppendText does not allocate if dst has enough
+// capacity. The base must be in [2, 62].
+func (x {{.Name}}) AppendText(dst []byte, base int) []byte {
+	w := x.words()
+	return appendWords(dst, w[:], base)
+}
+
+// Bit returns the value of bit at index i.
+//
+// That is, Bit returns (x>>i)&1.
+// The index must be >= 0.
+func (x {{.Name}}) Bit(i int) uint {
+	if i < 0 {
+		panic("negative bit index")
+	}
+	if i >= {{.Bits}} {
+		return 0
+	}
+	w := x.words()
+	return uint(w[i/64] >> (i % 64) & 1)
+}
+
+// BitLen returns the absolute value of x in bits.
+//
+// In other words, the number of bits needed to
+// represent x.
+func (x {{.Name}}) BitLen() int {
+	w := x.words()
+	for i := len(w) - 1; i >= 0; i-- {
+		if w[i] != 0 {
+			return 64*i + bits.Len64(w[i])
+		}
+	}
+	return 0
+}
+
+// Bytes{{.Bytes}} returns the big-endian representation of x.
+func (x {{.Name}}) Bytes{{.Bytes}}() [{{.Bytes}}]byte {
+	var b [{{.Bytes}}]byte
+	x.PutBE(b[:])
+	return b
+}
+
+// Cmp compares u and x and returns
+//
+//	+1 if x > y
+//	 0 if x == y
+//	-1 if x < y
+//
+// See CmpCT for a constant-time version.
+func (x {{.Name}}) Cmp(y {{.Name}}) int {
+	xw, yw := x.words(), y.words()
+	b := subVV(xw[:], xw[:], yw[:])
+
+	var r uint64
+	for _, v := range xw {
+		r |= v
+	}
+	// If r == 0 then x == y
+	// If r != 0 then x != y
+	// If b == 0 then x >= y
+	// If b == 1 then x < y
+	if b == 1 {
+		return -1
+	}
+	if r == 0 {
+		return +0
+	}
+	return +1
+}
+
+// CmpCT compares x and y and returns
+//
+//	lt = 1 if x < y and 0 otherwise
+//	eq = 1 if x == y and 0 otherwise
+//
+// so x > y if lt|eq == 0.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) CmpCT(y {{.Name}}) (lt, eq uint64) {
+	xw, yw := x.words(), y.words()
+	b := subVV(xw[:], xw[:], yw[:])
+
+	var r uint64
+	for _, v := range xw {
+		r |= v
+	}
+	return b, ct.Equal64(r, 0)
+}
+
+// Eq returns 1 if x == y and 0 otherwise.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) Eq(y {{.Name}}) uint64 {
+	xw, yw := x.words(), y.words()
+	var r uint64
+	for i := range xw {
+		r |= xw[i] ^ yw[i]
+	}
+	return ct.Equal64(r, 0)
+}
+
+// Exp returns x**y mod m.
+//
+// See ExpCT for a constant-time version.
+func (x {{.Name}}) Exp(y, m {{.Name}}) {{.Name}} {
+	z := U{{.Bits}}(1).Rem(m)
+	for i := y.BitLen() - 1; i >= 0; i-- {
+		z = z.MulMod(z, m)
+		if y.Bit(i) == 1 {
+			z = z.MulMod(x, m)
+		}
+	}
+	return z
+}
+
+// ExpCT returns x**y mod m.
+//
+// ExpCT panics if m == 0.
+//
+// If m is odd, ExpCT uses Montgomery multiplication.
+// Otherwise, it uses MulModCT, which is much slower.
+//
+// This function's execution time does not depend on x or y.
+func (x {{.Name}}) ExpCT(y, m {{.Name}}) {{.Name}} {
+	if m.BitLen() == 0 {
+		panic("division by zero")
+	}
+	if m.u0&1 == 0 {
+		mul := func(a, b {{.Name}}) {{.Name}} {
+			return a.MulModCT(b, m)
+		}
+		return ladder{{.Bits}}(x.ModCT(m), U{{.Bits}}(1).ModCT(m), y, mul)
+	}
+
+	k := montInverse(m.u0)
+	mw := m.words()
+	mul := func(a, b {{.Name}}) {{.Name}} {
+		aw, bw := a.words(), b.words()
+		montMulWords(aw[:], aw[:], bw[:], mw[:], k)
+		return u{{.Bits}}(aw)
+	}
+
+	// r2 = R^2 mod m.
+	var r2 [{{.Words}}]uint64
+	var u [{{.DoubleWords}} + 1]uint64
+	u[len(u)-1] = 1
+	quoRemCTWords(nil, r2[:], u[:], mw[:])
+
+	one := mul(U{{.Bits}}(1), u{{.Bits}}(r2))
+	z := ladder{{.Bits}}(mul(x.ModCT(m), u{{.Bits}}(r2)), one, y, mul)
+	return mul(z, U{{.Bits}}(1))
+}
+
+// FillBytes sets buf to x, storing it as a zero-extended
+// big-endian byte slice, and returns buf.
+//
+// If buf is smaller than {{.Bytes}} bytes, FillBytes will panic.
+func (x {{.Name}}) FillBytes(buf []byte) []byte {
+	w := x.words()
+	return fillBytes(buf, w[:], "FillBytes")
+}
+
+func (x {{.Name}}) Format(s fmt.State, ch rune) {
+	w := x.words()
+	format(s, ch, "{{.Name}}", false, w[:])
+}
+{{with .Half}}
+// Hi returns the high {{.Bits}} bits of x.
+func (x {{$.Name}}) Hi() {{.Name}} {
+	w := x.words()
+	var z [{{.Words}}]uint64
+	copy(z[:], w[{{.Words}}:])
+	return u{{.Bits}}(z)
+}
+{{end}}
+// IsUint64 reports whether x can be represented as
+// a uint64.
+func (x {{.Name}}) IsUint64() bool {
+	w := x.words()
+	var r uint64
+	for _, v := range w[1:] {
+		r |= v
+	}
+	return r == 0
+}
+
+// IsZero returns 1 if x == 0 and 0 otherwise.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) IsZero() uint64 {
+	var r uint64
+	for _, v := range x.words() {
+		r |= v
+	}
+	return ct.Equal64(r, 0)
+}
+
+// LeadingZeros returns the number of leading
+// zero bits in x.
+//
+// The result is {{.Bits}} if x == 0.
+func (x {{.Name}}) LeadingZeros() int {
+	return {{.Bits}} - x.BitLen()
+}
+
+{{with .Half}}
+// Lo returns the low {{.Bits}} bits of x.
+func (x {{$.Name}}) Lo() {{.Name}} {
+	w := x.words()
+	var z [{{.Words}}]uint64
+	copy(z[:], w[:{{.Words}}])
+	return u{{.Bits}}(z)
+}
+{{end}}
+// Lsh returns x<<n.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) Lsh(n uint) {{.Name}} {
+	s := n % 64
+	ŝ := 64 - s
+
+	// If n is in [0, {{.Bits}}) set i = n/64.
+	// Otherwise, set i = {{.Words}}.
+	i := subtle.ConstantTimeSelect(int(ct.LessOrEq(n, {{.Bits}}-1)), int(n/64), {{.Words}})
+
+	w := x.words()
+	var res [2 * {{.Words}}]uint64
+	for j := len(w) - 1; j > 0; j-- {
+		res[i+j] = w[j]<<s | w[j-1]>>ŝ
+	}
+	res[i] = w[0] << s
+
+	var z [{{.Words}}]uint64
+	copy(z[:], res[:{{.Words}}])
+	return u{{.Bits}}(z)
+}
+
+// Lt returns 1 if x < y and 0 otherwise.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) Lt(y {{.Name}}) uint64 {
+	xw, yw := x.words(), y.words()
+	return subVV(xw[:], xw[:], yw[:])
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// The result is always {{.Bytes}} bytes, the big-endian
+// representation of x.
+func (x {{.Name}}) MarshalBinary() ([]byte, error) {
+	return x.FillBytes(make([]byte, {{.Bytes}})), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+//
+// The result is the decimal representation of x.
+func (x {{.Name}}) MarshalText() ([]byte, error) {
+	return x.AppendText(nil, 10), nil
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The result is a quoted decimal string.
+func (x {{.Name}}) MarshalJSON() ([]byte, error) {
+	b := append(make([]byte, 0, {{.Bits}}/3+3), '"')
+	b = x.AppendText(b, 10)
+	return append(b, '"'), nil
+}
+
+// ModCT returns x mod m.
+//
+// ModCT panics if m == 0.
+//
+// This function's execution time does not depend on x or
+// m, except for whether m == 0.
+func (x {{.Name}}) ModCT(m {{.Name}}) {{.Name}} {
+	if m.IsZero() == 1 {
+		panic("division by zero")
+	}
+	xw, mw := x.words(), m.words()
+	var r [{{.Words}}]uint64
+	quoRemCTWords(nil, r[:], xw[:], mw[:])
+	return u{{.Bits}}(r)
+}
+
+// ModInverse returns the multiplicative inverse
+// of x in the ring ℤ/nℤ.
+//
+// If x and n are not relatively prime, x has no
+// multiplicative inverse in the ring ℤ/nℤ and
+// ModInverse will panic.
+//
+// If n is odd, ModInverse uses ModInverseCT. Otherwise, it
+// combines ModInverseCT modulo the odd part of n with
+// Newton's method modulo the power of two, which is not
+// constant time.
+func (x {{.Name}}) ModInverse(n {{.Name}}) {{.Name}} {
+	if n.BitLen() == 0 {
+		panic("division by zero")
+	}
+	if n.u0&1 == 1 {
+		z, ok := x.ModInverseCT(n)
+		if ok != 1 {
+			panic("xbits: no multiplicative inverse")
+		}
+		return z
+	}
+	if x.u0&1 == 0 {
+		panic("xbits: no multiplicative inverse")
+	}
+
+	// Write n = 2^k * o for an odd o and find
+	//
+	//    a = x^-1 mod o
+	//    b = x^-1 mod 2^k
+	//
+	// then combine them with the Chinese remainder theorem:
+	//
+	//    z = a + o*((b-a)*o^-1 mod 2^k)
+	//
+	// Since z < o*2^k = n, nothing overflows.
+	k := uint(n.TrailingZeros())
+	o := n.Rsh(k)
+	a, ok := x.ModInverseCT(o)
+	if ok != 1 {
+		panic("xbits: no multiplicative inverse")
+	}
+	mask := U{{.Bits}}(1).Lsh(k).Sub(U{{.Bits}}(1))
+	b := x.inverse2k()
+	t := b.Sub(a).Mul(o.inverse2k()).And(mask)
+	return a.Add(o.Mul(t))
+}
+
+// inverse2k returns x^-1 mod 2^{{.Bits}} for an odd x.
+func (x {{.Name}}) inverse2k() {{.Name}} {
+	// Newton's method. Since x is odd, x*x = 1 (mod 8), so
+	// the initial estimate is correct to three bits. Each
+	// iteration doubles the number of correct bits.
+	z := x
+	for n := 3; n < {{.Bits}}; n *= 2 {
+		z = z.Mul(U{{.Bits}}(2).Sub(x.Mul(z)))
+	}
+	return z
+}
+
+// ModInverseCT returns the multiplicative inverse
+// of x in the ring ℤ/nℤ and 1, or an unspecified
+// value and 0 if x and n are not relatively prime.
+//
+// ModInverseCT panics if n is even.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x {{.Name}}) ModInverseCT(n {{.Name}}) ({{.Name}}, uint64) {
+	if n.u0&1 == 0 {
+		panic("xbits: ModInverseCT: modulus must be odd")
+	}
+	a, nw := x.words(), n.words()
+	var v [{{.Words}}]uint64
+	ok := modInverseCTWords(v[:], a[:], nw[:])
+	return u{{.Bits}}(v), ok
+}
+
+// Mul returns x * y.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) Mul(y {{.Name}}) {{.Name}} {
+	xw, yw := x.words(), y.words()
+	var z [{{.Words}}]uint64
+	for i := range yw {
+		addMulVVW(z[i:], xw[:len(xw)-i], yw[i])
+	}
+	return u{{.Bits}}(z)
+}
+
+// MulChecked returns x * y and reports whether
+// the product did not overflow.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) MulChecked(y {{.Name}}) ({{.Name}}, bool) {
+	z, overflow := x.mulChecked(y)
+	return u{{.Bits}}(z), overflow == 0
+}
+
+// mulChecked returns the low words of x * y and 1 if the
+// product overflowed or 0 otherwise.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) mulChecked(y {{.Name}}) ([{{.Words}}]uint64, uint64) {
+	xw, yw := x.words(), y.words()
+	var z [2 * {{.Words}}]uint64
+	mulWords(z[:], xw[:], yw[:])
+	var r uint64
+	for _, v := range z[{{.Words}}:] {
+		r |= v
+	}
+	var lo [{{.Words}}]uint64
+	copy(lo[:], z[:])
+	return lo, nonzero64(r)
+}
+
+{{with .Wide}}
+// MulFull returns the full {{.Bits}}-bit product of x * y.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{$.Name}}) MulFull(y {{$.Name}}) {{.Name}} {
+	xw, yw := x.words(), y.words()
+	var z [{{.Words}}]uint64
+	mulWords(z[:], xw[:], yw[:])
+	return u{{.Bits}}(z)
+}
+{{end}}
+// MulMod returns x*y mod m.
+//
+// See MulModCT for a constant-time version.
+func (x {{.Name}}) MulMod(y, m {{.Name}}) {{.Name}} {
+	xw, yw := x.words(), y.words()
+	// div512 requires an extra zero word.
+	var z [{{.DoubleWords}} + 1]uint64
+	mulWords(z[:{{.DoubleWords}}], xw[:], yw[:])
+	return m.remWide(&z)
+}
+
+// remWide returns z[:{{.DoubleWords}}] mod m.
+//
+// z[{{.DoubleWords}}] must be zero.
+func (m {{.Name}}) remWide(z *[{{.DoubleWords}} + 1]uint64) {{.Name}} {
+	if l := m.BitLen(); l <= 64 {
+		if l == 0 {
+			panic("division by zero")
+		}
+		return U{{.Bits}}(mod64(z[:], m.u0))
+	}
+
+	v := m.words()
+	var q [{{.DoubleWords}} + 1]uint64
+	r := div512(q[:], z[:], v[:])
+
+	var rem [{{.Words}}]uint64
+	copy(rem[:], r)
+	return u{{.Bits}}(rem)
+}
+
+// MulModCT returns x*y mod m.
+//
+// MulModCT panics if m == 0.
+//
+// This function's execution time does not depend on x, y,
+// or m, except for whether m == 0.
+func (x {{.Name}}) MulModCT(y, m {{.Name}}) {{.Name}} {
+	if m.IsZero() == 1 {
+		panic("division by zero")
+	}
+	xw, yw, mw := x.words(), y.words(), m.words()
+	var z [2 * {{.Words}}]uint64
+	mulWords(z[:], xw[:], yw[:])
+	var r [{{.Words}}]uint64
+	quoRemCTWords(nil, r[:], z[:], mw[:])
+	return u{{.Bits}}(r)
+}
+
+// MulSat returns x * y, saturating at 1<<{{.Bits}}-1 instead
+// of overflowing.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) MulSat(y {{.Name}}) {{.Name}} {
+	z, overflow := x.mulChecked(y)
+	for i := range z {
+		z[i] |= -overflow
+	}
+	return u{{.Bits}}(z)
+}
+
+// OnesCount returns the number of one bits
+// in x.
+//
+// Also known as the "population count."
+func (x {{.Name}}) OnesCount() int {
+	var n int
+	for _, v := range x.words() {
+		n += bits.OnesCount64(v)
+	}
+	return n
+}
+
+// Or returns x | y.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) Or(y {{.Name}}) {{.Name}} {
+	xw, yw := x.words(), y.words()
+	for i := range xw {
+		xw[i] |= yw[i]
+	}
+	return u{{.Bits}}(xw)
+}
+
+// PutBE stores x into buf[:{{.Bytes}}] in big-endian order.
+//
+// If buf is smaller than {{.Bytes}} bytes, PutBE will panic.
+func (x {{.Name}}) PutBE(buf []byte) {
+	if len(buf) < {{.Bytes}} {
+		panic("PutBE: buffer too small")
+	}
+	for i, v := range x.words() {
+		binary.BigEndian.PutUint64(buf[{{.Bytes}}-8-8*i:], v)
+	}
+}
+
+// PutLE stores x into buf[:{{.Bytes}}] in little-endian order.
+//
+// If buf is smaller than {{.Bytes}} bytes, PutLE will panic.
+func (x {{.Name}}) PutLE(buf []byte) {
+	if len(buf) < {{.Bytes}} {
+		panic("PutLE: buffer too small")
+	}
+	for i, v := range x.words() {
+		binary.LittleEndian.PutUint64(buf[8*i:], v)
+	}
+}
+
+// Quo returns x / y.
+//
+// Quo implements truncated division, like Go.
+func (x {{.Name}}) Quo(y {{.Name}}) {{.Name}} {
+	q, _ := x.QuoRem(y)
+	return q
+}
+
+// QuoRem returns x / y and x % y.
+//
+// QuoRem implements truncated division and
+// modulus, like Go.
+func (x {{.Name}}) QuoRem(y {{.Name}}) ({{.Name}}, {{.Name}}) {
+	if l := y.BitLen(); l <= 64 {
+		if l == 0 {
+			panic("division by zero")
+		}
+		y := y.u0
+		rec := reciprocal(y)
+		u := x.words()
+		var q [{{.Words}}]uint64
+		var r uint64
+		for i := len(u) - 1; i >= 0; i-- {
+			q[i], r = divWW(r, u[i], y, rec)
+		}
+		return u{{.Bits}}(q), U{{.Bits}}(r)
+	}
+
+	var u [{{.Words}} + 1]uint64
+	xw := x.words()
+	copy(u[:], xw[:])
+
+	v := y.words()
+
+	var q [{{.Words}} + 1]uint64
+	r := div512(q[:], u[:], v[:])
+
+	var quo, rem [{{.Words}}]uint64
+	copy(quo[:], q[:])
+	copy(rem[:], r)
+	return u{{.Bits}}(quo), u{{.Bits}}(rem)
+}
+
+// QuoRemCT returns x / y and x % y.
+//
+// QuoRemCT panics if y == 0.
+//
+// This function's execution time does not depend on x or
+// y, except for whether y == 0.
+func (x {{.Name}}) QuoRemCT(y {{.Name}}) ({{.Name}}, {{.Name}}) {
+	if y.IsZero() == 1 {
+		panic("division by zero")
+	}
+	xw, yw := x.words(), y.words()
+	var q, r [{{.Words}}]uint64
+	quoRemCTWords(q[:], r[:], xw[:], yw[:])
+	return u{{.Bits}}(q), u{{.Bits}}(r)
+}
+
+// Rem returns x % y.
+//
+// Rem implements truncated modulus, like Go.
+func (x {{.Name}}) Rem(y {{.Name}}) {{.Name}} {
+	_, r := x.QuoRem(y)
+	return r
+}
+
+// RotateLeft returns the value of x rotated left
+// by (k mod {{.Bits}}) bits.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) RotateLeft(k int) {{.Name}} {
+	const n = {{.Bits}}
+	s := uint((k%n + n) % n)
+	return x.Lsh(s).Or(x.Rsh(n - s))
+}
+
+// RotateRight returns the value of x rotated right
+// by (k mod {{.Bits}}) bits.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) RotateRight(k int) {{.Name}} {
+	return x.RotateLeft(-k)
+}
+
+// Reverse returns the value of x with its bits in
+// reversed order.
+func (x {{.Name}}) Reverse() {{.Name}} {
+	w := x.words()
+	var z [{{.Words}}]uint64
+	for i, v := range w {
+		z[len(z)-1-i] = bits.Reverse64(v)
+	}
+	return u{{.Bits}}(z)
+}
+
+// ReverseBytes returns the value of x with its bytes
+// in reversed order.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) ReverseBytes() {{.Name}} {
+	w := x.words()
+	var z [{{.Words}}]uint64
+	for i, v := range w {
+		z[len(z)-1-i] = bits.ReverseBytes64(v)
+	}
+	return u{{.Bits}}(z)
+}
+
+// Rsh returns x>>n.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) Rsh(n uint) {{.Name}} {
+	s := n % 64
+	ŝ := 64 - s
+
+	w := x.words()
+	var res [2 * {{.Words}}]uint64
+	for j := 0; j < len(w)-1; j++ {
+		res[j] = w[j]>>s | w[j+1]<<ŝ
+	}
+	res[len(w)-1] = w[len(w)-1] >> s
+
+	// If n is in [0, {{.Bits}}) set i = n/64.
+	// Otherwise, set i = {{.Words}}.
+	i := subtle.ConstantTimeSelect(int(ct.LessOrEq(n, {{.Bits}}-1)), int(n/64), {{.Words}})
+
+	var z [{{.Words}}]uint64
+	copy(z[:], res[i:])
+	return u{{.Bits}}(z)
+}
+
+// Scan is a support routine for fmt.Scanner; it sets z to
+// the value of the scanned number.
+//
+// It accepts the same formats as Uint256.Scan.
+func (z *{{.Name}}) Scan(s fmt.ScanState, ch rune) error {
+	tok, base, err := scanToken(s, ch, "{{.Name}}")
+	if err != nil {
+		return err
+	}
+	return z.setString(tok, base, "Scan")
+}
+
+// SetBytes sets z to the big-endian unsigned integer buf.
+//
+// SetBytes panics if buf overflows z (buf > 1<<{{.Bits}}-1).
+func (z *{{.Name}}) SetBytes(buf []byte) {
+	var w [{{.Words}}]uint64
+	setBytes(w[:], buf, "SetBytes")
+	*z = u{{.Bits}}(w)
+}
+
+// SetBytesChecked sets z to the big-endian unsigned
+// integer buf.
+//
+// Unlike SetBytes, buf may be longer than {{.Bytes}} bytes as long
+// as the extra leading bytes are zero. If buf overflows z
+// (buf > 1<<{{.Bits}}-1), SetBytesChecked returns an error and
+// leaves z unchanged.
+func (z *{{.Name}}) SetBytesChecked(buf []byte) error {
+	n := len(buf)
+	for len(buf) > {{.Bytes}} && buf[0] == 0 {
+		buf = buf[1:]
+	}
+	if len(buf) > {{.Bytes}} {
+		return fmt.Errorf("xbits: SetBytesChecked: integer too large: %d bytes", n)
+	}
+	z.SetBytes(buf)
+	return nil
+}
+
+// SetBytesLE sets z to the little-endian unsigned integer
+// buf.
+//
+// SetBytesLE panics if buf overflows z (buf > 1<<{{.Bits}}-1).
+func (z *{{.Name}}) SetBytesLE(buf []byte) {
+	if len(buf) > {{.Bytes}} {
+		panic("SetBytesLE: integer too large")
+	}
+	var b [{{.Bytes}}]byte
+	copy(b[:], buf)
+	var w [{{.Words}}]uint64
+	for i := range w {
+		w[i] = binary.LittleEndian.Uint64(b[8*i:])
+	}
+	*z = u{{.Bits}}(w)
+}
+
+// SetString sets z to the value of s, interpreted in the
+// given base.
+//
+// SetString accepts the same input as Parse{{.Name}}. If
+// SetString returns an error, z is left unchanged.
+func (z *{{.Name}}) SetString(s string, base int) error {
+	return z.setString(s, base, "SetString")
+}
+
+// setString implements SetString. fn is the name used in
+// errors.
+func (z *{{.Name}}) setString(s string, base int, fn string) error {
+	var w [{{.Words}}]uint64
+	if err := parseWords(w[:], s, base, fn); err != nil {
+		return err
+	}
+	*z = u{{.Bits}}(w)
+	return nil
+}
+
+// Sqr returns x^2.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) Sqr() {{.Name}} {
+	xw := x.words()
+	var z [2 * {{.Words}}]uint64
+	sqrWords(z[:], xw[:])
+	var lo [{{.Words}}]uint64
+	copy(lo[:], z[:])
+	return u{{.Bits}}(lo)
+}
+
+{{with .Wide}}
+// SqrFull returns the full {{.Bits}}-bit square of x.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{$.Name}}) SqrFull() {{.Name}} {
+	xw := x.words()
+	var z [{{.Words}}]uint64
+	sqrWords(z[:], xw[:])
+	return u{{.Bits}}(z)
+}
+{{end}}
+// Sqrt returns ⌊√x⌋, the largest integer z such that
+// z*z <= x.
+func (x {{.Name}}) Sqrt() {{.Name}} {
+	if x.IsUint64() && x.u0 < 2 {
+		return x
+	}
+	// Newton's method, as in Uint256.Sqrt.
+	z1 := U{{.Bits}}(1).Lsh(uint(x.BitLen()+1) / 2)
+	for {
+		z2 := z1.Add(x.Quo(z1)).Rsh(1)
+		if z2.Cmp(z1) >= 0 {
+			return z1
+		}
+		z1 = z2
+	}
+}
+
+// Sub returns x - y.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) Sub(y {{.Name}}) {{.Name}} {
+	xw, yw := x.words(), y.words()
+	subVV(xw[:], xw[:], yw[:])
+	return u{{.Bits}}(xw)
+}
+
+// SubChecked returns x - y and reports whether
+// the difference did not underflow.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) SubChecked(y {{.Name}}) ({{.Name}}, bool) {
+	xw, yw := x.words(), y.words()
+	b := subVV(xw[:], xw[:], yw[:])
+	return u{{.Bits}}(xw), b == 0
+}
+
+// SubSat returns x - y, saturating at 0 instead of
+// underflowing.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) SubSat(y {{.Name}}) {{.Name}} {
+	xw, yw := x.words(), y.words()
+	b := subVV(xw[:], xw[:], yw[:])
+	for i := range xw {
+		xw[i] &= b - 1
+	}
+	return u{{.Bits}}(xw)
+}
+
+func (x {{.Name}}) String() string {
+	return x.Text(10)
+}
+
+// Text returns the textual representation of
+// x in the provided base.
+//
+// The base must be in [2, 62].
+func (x {{.Name}}) Text(base int) string {
+	var buf [{{.Bits}}]byte
+	return string(x.AppendText(buf[:0], base))
+}
+
+// ToBig sets z to x and returns z.
+func (x {{.Name}}) ToBig(z *big.Int) *big.Int {
+	w := x.words()
+	setWords(z, w[:])
+	return z
+}
+
+// TrailingZeros returns the number of trailing
+// zero bits in x.
+//
+// The result is {{.Bits}} if x == 0.
+func (x {{.Name}}) TrailingZeros() int {
+	w := x.words()
+	for i, v := range w {
+		if v != 0 {
+			return 64*i + bits.TrailingZeros64(v)
+		}
+	}
+	return {{.Bits}}
+}
+
+// Uint64 returns the uint64 representation of x.
+//
+// The result is undefined if x cannot be
+// represented as a uint64.
+func (x {{.Name}}) Uint64() uint64 {
+	return x.u0
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// The data must be exactly {{.Bytes}} bytes, as produced by
+// MarshalBinary.
+func (z *{{.Name}}) UnmarshalBinary(data []byte) error {
+	if len(data) != {{.Bytes}} {
+		return fmt.Errorf("xbits: UnmarshalBinary: invalid length: %d", len(data))
+	}
+	z.SetBytes(data)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// It accepts both JSON numbers and JSON strings, like
+// Uint256.UnmarshalJSON.
+func (z *{{.Name}}) UnmarshalJSON(data []byte) error {
+	s, base, ok := jsonText(data)
+	if !ok {
+		return nil
+	}
+	return z.setString(s, base, "UnmarshalJSON")
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+//
+// The text is interpreted as if by Parse{{.Name}} with base
+// 0, so it can be decimal or have a base prefix like "0x".
+func (z *{{.Name}}) UnmarshalText(text []byte) error {
+	return z.setString(string(text), 0, "UnmarshalText")
+}
+
+// Xor returns x ^ y.
+//
+// This function's execution time does not depend on its inputs.
+func (x {{.Name}}) Xor(y {{.Name}}) {{.Name}} {
+	xw, yw := x.words(), y.words()
+	for i := range xw {
+		xw[i] ^= yw[i]
+	}
+	return u{{.Bits}}(xw)
+}
+
+// Add{{.Bits}} returns the sum of x, y and carry:
+// sum = x + y + carry.
+//
+// The carry input must be 0 or 1; otherwise the behavior
+// is undefined. The carryOut output is guaranteed to be 0
+// or 1.
+//
+// This function's execution time does not depend on its
+// inputs.
+func Add{{.Bits}}(x, y {{.Name}}, carry uint64) (sum {{.Name}}, carryOut uint64) {
+	xw, yw := x.words(), y.words()
+	c := carry
+	for i := range xw {
+		xw[i], c = bits.Add64(xw[i], yw[i], c)
+	}
+	return u{{.Bits}}(xw), c
+}
+
+// Sub{{.Bits}} returns the difference of x, y and borrow:
+// diff = x - y - borrow.
+//
+// The borrow input must be 0 or 1; otherwise the behavior
+// is undefined. The borrowOut output is guaranteed to be 0
+// or 1.
+//
+// This function's execution time does not depend on its
+// inputs.
+func Sub{{.Bits}}(x, y {{.Name}}, borrow uint64) (diff {{.Name}}, borrowOut uint64) {
+	xw, yw := x.words(), y.words()
+	b := borrow
+	for i := range xw {
+		xw[i], b = bits.Sub64(xw[i], yw[i], b)
+	}
+	return u{{.Bits}}(xw), b
+}
+
+// Mul{{.Bits}} returns the {{.DoubleBits}}-bit product of x and y:
+// (hi, lo) = x * y with the product bits' upper half
+// returned in hi and the lower half returned in lo.
+//
+// This function's execution time does not depend on its
+// inputs.
+func Mul{{.Bits}}(x, y {{.Name}}) (hi, lo {{.Name}}) {
+	xw, yw := x.words(), y.words()
+	var z [2 * {{.Words}}]uint64
+	mulWords(z[:], xw[:], yw[:])
+	var h, l [{{.Words}}]uint64
+	copy(l[:], z[:{{.Words}}])
+	copy(h[:], z[{{.Words}}:])
+	return u{{.Bits}}(h), u{{.Bits}}(l)
+}
+
+// Select{{.Bits}} returns x if v == 1 and y if v == 0.
+//
+// The result is undefined if v is anything
+// other than 1 or 0.
+//
+// This function's execution time does not depend on its
+// inputs.
+func Select{{.Bits}}(v uint64, x, y {{.Name}}) {{.Name}} {
+	xw, yw := x.words(), y.words()
+	var z [{{.Words}}]uint64
+	selectWords(v, z[:], xw[:], yw[:])
+	return u{{.Bits}}(z)
+}
+
+// CondSwap{{.Bits}} returns (y, x) if v == 1 and (x, y) if v == 0.
+//
+// The result is undefined if v is anything
+// other than 1 or 0.
+//
+// This function's execution time does not depend on its
+// inputs.
+func CondSwap{{.Bits}}(v uint64, x, y {{.Name}}) ({{.Name}}, {{.Name}}) {
+	xw, yw := x.words(), y.words()
+	condSwapWords(v, xw[:], yw[:])
+	return u{{.Bits}}(xw), u{{.Bits}}(yw)
+}
+
+// ladder{{.Bits}} returns x**y using a Montgomery ladder with
+// the provided multiplication.
+//
+// one is the multiplicative identity.
+//
+// This function's execution time does not depend on its
+// inputs, provided mul's does not.
+func ladder{{.Bits}}(x, one, y {{.Name}}, mul func(x, y {{.Name}}) {{.Name}}) {{.Name}} {
+	x1, x2 := one, x
+	for i := {{.Bits}} - 1; i >= 0; i-- {
+		// If the bit is 1, swap x1 and x2 so the same
+		// operations apply to both cases.
+		bit := uint64(y.Bit(i))
+		x1, x2 = CondSwap{{.Bits}}(bit, x1, x2)
+		x2 = mul(x1, x2)
+		x1 = mul(x1, x1)
+		x1, x2 = CondSwap{{.Bits}}(bit, x1, x2)
+	}
+	return x1
+}
+`))
+
+var testTmpl = template.Must(template.New("test").Parse(`// Code generated by gen_uint.go. DO NOT EDIT.
+
+package xbits
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// big{{.Bits}}Mask is 1<<{{.Bits}}-1.
+var big{{.Bits}}Mask = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), {{.Bits}}), big.NewInt(1))
+
+// rand{{.Bits}} returns a random {{.Name}} with a random
+// bit length.
+func rand{{.Bits}}(t testing.TB) {{.Name}} {
+	buf := make([]byte, {{.Bytes}})
+	if _, err := io.ReadFull(rng, buf); err != nil {
+		t.Fatal(err)
+	}
+	var x {{.Name}}
+	x.SetBytes(buf)
+	return x.Rsh(uint(rand.Intn({{.Bits}})))
+}
+
+func setInt{{.Bits}}(z *big.Int, x {{.Name}}) {
+	w := x.words()
+	setWords(z, w[:])
+}
+
+func cmpInt{{.Bits}}(x *big.Int, y {{.Name}}) int {
+	var yy big.Int
+	setInt{{.Bits}}(&yy, y)
+	return x.Cmp(&yy)
+}
+
+func Test{{.Name}}Binary(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		fn   func(x, y {{.Name}}) {{.Name}}
+		big  func(z, x, y *big.Int) *big.Int
+	}{
+		{"Add", {{.Name}}.Add, (*big.Int).Add},
+		{"Sub", {{.Name}}.Sub, (*big.Int).Sub},
+		{"Mul", {{.Name}}.Mul, (*big.Int).Mul},
+		{"And", {{.Name}}.And, (*big.Int).And},
+		{"Or", {{.Name}}.Or, (*big.Int).Or},
+		{"Xor", {{.Name}}.Xor, (*big.Int).Xor},
+	} {
+		for i := 0; i < 10_000; i++ {
+			x, y := rand{{.Bits}}(t), rand{{.Bits}}(t)
+			z := tc.fn(x, y)
+
+			var bz, bx, by big.Int
+			setInt{{.Bits}}(&bx, x)
+			setInt{{.Bits}}(&by, y)
+			tc.big(&bz, &bx, &by)
+			bz.And(&bz, big{{.Bits}}Mask)
+
+			if cmpInt{{.Bits}}(&bz, z) != 0 {
+				t.Fatalf("%s #%d: expected %s, got %d", tc.name, i, bz.String(), z)
+			}
+		}
+	}
+}
+
+func Test{{.Name}}Checked(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		fn   func(x, y {{.Name}}) ({{.Name}}, bool)
+		big  func(z, x, y *big.Int) *big.Int
+	}{
+		{"AddChecked", {{.Name}}.AddChecked, (*big.Int).Add},
+		{"SubChecked", {{.Name}}.SubChecked, (*big.Int).Sub},
+		{"MulChecked", {{.Name}}.MulChecked, (*big.Int).Mul},
+	} {
+		for i := 0; i < 10_000; i++ {
+			x, y := rand{{.Bits}}(t), rand{{.Bits}}(t)
+			z, ok := tc.fn(x, y)
+
+			var bz, bx, by big.Int
+			setInt{{.Bits}}(&bx, x)
+			setInt{{.Bits}}(&by, y)
+			tc.big(&bz, &bx, &by)
+			want := bz.Sign() >= 0 && bz.Cmp(big{{.Bits}}Mask) <= 0
+			bz.And(&bz, big{{.Bits}}Mask)
+
+			if cmpInt{{.Bits}}(&bz, z) != 0 || ok != want {
+				t.Fatalf("%s #%d: expected (%s, %t), got (%d, %t)",
+					tc.name, i, bz.String(), want, z, ok)
+			}
+		}
+	}
+}
+
+func Test{{.Name}}Sat(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		fn   func(x, y {{.Name}}) {{.Name}}
+		big  func(z, x, y *big.Int) *big.Int
+	}{
+		{"AddSat", {{.Name}}.AddSat, (*big.Int).Add},
+		{"SubSat", {{.Name}}.SubSat, (*big.Int).Sub},
+		{"MulSat", {{.Name}}.MulSat, (*big.Int).Mul},
+	} {
+		for i := 0; i < 10_000; i++ {
+			x, y := rand{{.Bits}}(t), rand{{.Bits}}(t)
+			z := tc.fn(x, y)
+
+			var bz, bx, by big.Int
+			setInt{{.Bits}}(&bx, x)
+			setInt{{.Bits}}(&by, y)
+			tc.big(&bz, &bx, &by)
+			switch {
+			case bz.Sign() < 0:
+				bz.SetInt64(0)
+			case bz.Cmp(big{{.Bits}}Mask) > 0:
+				bz.Set(big{{.Bits}}Mask)
+			}
+
+			if cmpInt{{.Bits}}(&bz, z) != 0 {
+				t.Fatalf("%s #%d: expected %s, got %d", tc.name, i, bz.String(), z)
+			}
+		}
+	}
+}
+
+func Test{{.Name}}Full(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x, y := rand{{.Bits}}(t), rand{{.Bits}}(t)
+		c := uint64(rand.Intn(2))
+
+		var bz, bx, by, bc big.Int
+		setInt{{.Bits}}(&bx, x)
+		setInt{{.Bits}}(&by, y)
+		bc.SetUint64(c)
+
+		sum, carry := Add{{.Bits}}(x, y, c)
+		bz.Add(&bx, &by)
+		bz.Add(&bz, &bc)
+		if cmpInt{{.Bits}}(new(big.Int).And(&bz, big{{.Bits}}Mask), sum) != 0 ||
+			bz.Rsh(&bz, {{.Bits}}).Uint64() != carry {
+			t.Fatalf("#%d: Add{{.Bits}}(%d, %d, %d): got (%d, %d)", i, x, y, c, sum, carry)
+		}
+
+		diff, borrow := Sub{{.Bits}}(x, y, c)
+		bz.Sub(&bx, &by)
+		bz.Sub(&bz, &bc)
+		want := uint64(0)
+		if bz.Sign() < 0 {
+			want = 1
+		}
+		if cmpInt{{.Bits}}(bz.And(&bz, big{{.Bits}}Mask), diff) != 0 || borrow != want {
+			t.Fatalf("#%d: Sub{{.Bits}}(%d, %d, %d): got (%d, %d)", i, x, y, c, diff, borrow)
+		}
+
+		hi, lo := Mul{{.Bits}}(x, y)
+		bz.Mul(&bx, &by)
+		if cmpInt{{.Bits}}(new(big.Int).And(&bz, big{{.Bits}}Mask), lo) != 0 ||
+			cmpInt{{.Bits}}(bz.Rsh(&bz, {{.Bits}}), hi) != 0 {
+			t.Fatalf("#%d: Mul{{.Bits}}(%d, %d): got (%d, %d)", i, x, y, hi, lo)
+		}
+
+		bz.Mul(&bx, &bx)
+		bz.And(&bz, big{{.Bits}}Mask)
+		if z := x.Sqr(); cmpInt{{.Bits}}(&bz, z) != 0 {
+			t.Fatalf("#%d: Sqr(%d): expected %s, got %d", i, x, bz.String(), z)
+		}
+
+		bz.Sqrt(&bx)
+		if z := x.Sqrt(); cmpInt{{.Bits}}(&bz, z) != 0 {
+			t.Fatalf("#%d: Sqrt(%d): expected %s, got %d", i, x, bz.String(), z)
+		}
+	}
+}
+
+func Test{{.Name}}QuoRem(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		x, y := rand{{.Bits}}(t), rand{{.Bits}}(t)
+		if y.BitLen() == 0 {
+			y = U{{.Bits}}(1)
+		}
+
+		var bq, br, bx, by big.Int
+		setInt{{.Bits}}(&bx, x)
+		setInt{{.Bits}}(&by, y)
+		bq.QuoRem(&bx, &by, &br)
+		q, r := x.QuoRem(y)
+
+		if cmpInt{{.Bits}}(&bq, q) != 0 {
+			t.Fatalf("#%d: %d/%d: expected %s, got %d", i, x, y, bq.String(), q)
+		}
+		if cmpInt{{.Bits}}(&br, r) != 0 {
+			t.Fatalf("#%d: %d%%%d: expected %s, got %d", i, x, y, br.String(), r)
+		}
+	}
+}
+
+{{with .Wide}}
+func Test{{$.Name}}MulFull(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x, y := rand{{$.Bits}}(t), rand{{$.Bits}}(t)
+
+		hi, lo := Mul{{$.Bits}}(x, y)
+		hw, lw := hi.words(), lo.words()
+		var w [{{.Words}}]uint64
+		copy(w[:], lw[:])
+		copy(w[{{$.Words}}:], hw[:])
+		if z := x.MulFull(y); z != u{{.Bits}}(w) {
+			t.Fatalf("#%d: MulFull(%d, %d): expected %d, got %d", i, x, y, u{{.Bits}}(w), z)
+		}
+		if z, want := x.SqrFull(), x.MulFull(x); z != want {
+			t.Fatalf("#%d: SqrFull(%d): expected %d, got %d", i, x, want, z)
+		}
+	}
+}
+{{end}}
+func Test{{.Name}}QuoRemCT(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		x, y := rand{{.Bits}}(t), rand{{.Bits}}(t)
+		if y.BitLen() == 0 {
+			y = U{{.Bits}}(1)
+		}
+
+		q, r := x.QuoRemCT(y)
+		if wq, wr := x.QuoRem(y); q != wq || r != wr {
+			t.Fatalf("#%d: %d/%d: expected (%d, %d), got (%d, %d)", i, x, y, wq, wr, q, r)
+		}
+		if z := x.ModCT(y); z != r {
+			t.Fatalf("#%d: %d mod %d: expected %d, got %d", i, x, y, r, z)
+		}
+	}
+}
+
+func Test{{.Name}}MulMod(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		x, y, m := rand{{.Bits}}(t), rand{{.Bits}}(t), rand{{.Bits}}(t)
+		if m.BitLen() == 0 {
+			m = U{{.Bits}}(1)
+		}
+
+		var bz, bx, by, bm big.Int
+		setInt{{.Bits}}(&bx, x)
+		setInt{{.Bits}}(&by, y)
+		setInt{{.Bits}}(&bm, m)
+		bz.Mul(&bx, &by)
+		bz.Mod(&bz, &bm)
+
+		if z := x.MulMod(y, m); cmpInt{{.Bits}}(&bz, z) != 0 {
+			t.Fatalf("#%d: MulMod(%d, %d, %d): expected %s, got %d", i, x, y, m, bz.String(), z)
+		}
+		if z := x.MulModCT(y, m); cmpInt{{.Bits}}(&bz, z) != 0 {
+			t.Fatalf("#%d: MulModCT(%d, %d, %d): expected %s, got %d", i, x, y, m, bz.String(), z)
+		}
+	}
+}
+
+func Test{{.Name}}Exp(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		x, y, m := rand{{.Bits}}(t), rand{{.Bits}}(t), rand{{.Bits}}(t)
+		// ExpCT is slow for even moduli, so only test a few.
+		if i%10 != 0 {
+			m.u0 |= 1
+		}
+		if m.BitLen() == 0 {
+			m = U{{.Bits}}(1)
+		}
+
+		var bz, bx, by, bm big.Int
+		setInt{{.Bits}}(&bx, x)
+		setInt{{.Bits}}(&by, y)
+		setInt{{.Bits}}(&bm, m)
+		bz.Exp(&bx, &by, &bm)
+
+		if z := x.Exp(y, m); cmpInt{{.Bits}}(&bz, z) != 0 {
+			t.Fatalf("#%d: Exp(%d, %d, %d): expected %s, got %d", i, x, y, m, bz.String(), z)
+		}
+		if z := x.ExpCT(y, m); cmpInt{{.Bits}}(&bz, z) != 0 {
+			t.Fatalf("#%d: ExpCT(%d, %d, %d): expected %s, got %d", i, x, y, m, bz.String(), z)
+		}
+	}
+}
+
+func Test{{.Name}}ModInverse(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		x, n := rand{{.Bits}}(t), rand{{.Bits}}(t)
+		if n.BitLen() == 0 {
+			n = U{{.Bits}}(1)
+		}
+
+		var bz, bx, bn big.Int
+		setInt{{.Bits}}(&bx, x)
+		setInt{{.Bits}}(&bn, n)
+		ok := bz.ModInverse(&bx, &bn) != nil
+
+		if n.u0&1 == 1 {
+			z, got := x.ModInverseCT(n)
+			if (got == 1) != ok || (ok && cmpInt{{.Bits}}(&bz, z) != 0) {
+				t.Fatalf("#%d: ModInverseCT(%d, %d): expected (%s, %t), got (%d, %d)",
+					i, x, n, bz.String(), ok, z, got)
+			}
+		}
+		if !ok {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatalf("#%d: ModInverse(%d, %d): expected a panic", i, x, n)
+					}
+				}()
+				x.ModInverse(n)
+			}()
+			continue
+		}
+		if z := x.ModInverse(n); cmpInt{{.Bits}}(&bz, z) != 0 {
+			t.Fatalf("#%d: ModInverse(%d, %d): expected %s, got %d", i, x, n, bz.String(), z)
+		}
+	}
+}
+
+func Test{{.Name}}Shift(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		x := rand{{.Bits}}(t)
+		n := uint(rand.Intn(2 * {{.Bits}}))
+
+		var bz, bx big.Int
+		setInt{{.Bits}}(&bx, x)
+
+		bz.Lsh(&bx, n)
+		bz.And(&bz, big{{.Bits}}Mask)
+		if z := x.Lsh(n); cmpInt{{.Bits}}(&bz, z) != 0 {
+			t.Fatalf("#%d: %d<<%d: expected %s, got %d", i, x, n, bz.String(), z)
+		}
+
+		bz.Rsh(&bx, n)
+		if z := x.Rsh(n); cmpInt{{.Bits}}(&bz, z) != 0 {
+			t.Fatalf("#%d: %d>>%d: expected %s, got %d", i, x, n, bz.String(), z)
+		}
+
+		k := int(n) % {{.Bits}}
+		bz.Lsh(&bx, uint(k))
+		bz.Or(&bz, new(big.Int).Rsh(&bx, uint({{.Bits}}-k)))
+		bz.And(&bz, big{{.Bits}}Mask)
+		if z := x.RotateLeft(k); cmpInt{{.Bits}}(&bz, z) != 0 {
+			t.Fatalf("#%d: %d<<<%d: expected %s, got %d", i, x, k, bz.String(), z)
+		}
+		if z := x.RotateLeft(k).RotateRight(k); z != x {
+			t.Fatalf("#%d: RotateRight(RotateLeft(%d, %d)) = %d", i, x, k, z)
+		}
+		if z := x.RotateLeft(-k); z != x.RotateRight(k) {
+			t.Fatalf("#%d: RotateLeft(%d, -%d) = %d", i, x, k, z)
+		}
+	}
+}
+
+func Test{{.Name}}Select(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		x, y := rand{{.Bits}}(t), rand{{.Bits}}(t)
+		if z := Select{{.Bits}}(1, x, y); z != x {
+			t.Fatalf("#%d: Select{{.Bits}}(1, %d, %d): got %d", i, x, y, z)
+		}
+		if z := Select{{.Bits}}(0, x, y); z != y {
+			t.Fatalf("#%d: Select{{.Bits}}(0, %d, %d): got %d", i, x, y, z)
+		}
+		if a, b := CondSwap{{.Bits}}(1, x, y); a != y || b != x {
+			t.Fatalf("#%d: CondSwap{{.Bits}}(1, %d, %d): got (%d, %d)", i, x, y, a, b)
+		}
+		if a, b := CondSwap{{.Bits}}(0, x, y); a != x || b != y {
+			t.Fatalf("#%d: CondSwap{{.Bits}}(0, %d, %d): got (%d, %d)", i, x, y, a, b)
+		}
+	}
+}
+
+func Test{{.Name}}Bits(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x := rand{{.Bits}}(t)
+
+		var bx big.Int
+		setInt{{.Bits}}(&bx, x)
+
+		if got, want := x.BitLen(), bx.BitLen(); got != want {
+			t.Fatalf("#%d: BitLen(%d): expected %d, got %d", i, x, want, got)
+		}
+		if got, want := x.LeadingZeros(), {{.Bits}}-bx.BitLen(); got != want {
+			t.Fatalf("#%d: LeadingZeros(%d): expected %d, got %d", i, x, want, got)
+		}
+		want := {{.Bits}}
+		if bx.Sign() != 0 {
+			want = int(bx.TrailingZeroBits())
+		}
+		if got := x.TrailingZeros(); got != want {
+			t.Fatalf("#%d: TrailingZeros(%d): expected %d, got %d", i, x, want, got)
+		}
+		for j := 0; j < {{.Bits}}*2; j++ {
+			if got, want := x.Bit(j), bx.Bit(j); got != want {
+				t.Fatalf("#%d: Bit(%d, %d): expected %d, got %d", i, x, j, want, got)
+			}
+		}
+		if z := x.Reverse().Reverse(); z != x {
+			t.Fatalf("#%d: Reverse(Reverse(%d)) = %d", i, x, z)
+		}
+		if z := x.ReverseBytes().ReverseBytes(); z != x {
+			t.Fatalf("#%d: ReverseBytes(ReverseBytes(%d)) = %d", i, x, z)
+		}
+		if x.Reverse().Bit({{.Bits}}-1) != x.Bit(0) {
+			t.Fatalf("#%d: Reverse(%d): bit 0 not moved to bit {{.Bits}}-1", i, x)
+		}
+		if got, want := x.IsUint64(), bx.IsUint64(); got != want {
+			t.Fatalf("#%d: IsUint64(%d): expected %t, got %t", i, x, want, got)
+		}
+		if got, want := x.IsZero() == 1, bx.Sign() == 0; got != want {
+			t.Fatalf("#%d: IsZero(%d): expected %t, got %t", i, x, want, got)
+		}
+	}
+}
+
+func Test{{.Name}}Cmp(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x, y := rand{{.Bits}}(t), rand{{.Bits}}(t)
+		if rand.Intn(4) == 0 {
+			y = x
+		}
+
+		var bx, by big.Int
+		setInt{{.Bits}}(&bx, x)
+		setInt{{.Bits}}(&by, y)
+		want := bx.Cmp(&by)
+		if got := x.Cmp(y); got != want {
+			t.Fatalf("#%d: Cmp(%d, %d): expected %d, got %d", i, x, y, want, got)
+		}
+		if lt, eq := x.CmpCT(y); (lt == 1) != (want < 0) || (eq == 1) != (want == 0) || lt&eq != 0 {
+			t.Fatalf("#%d: CmpCT(%d, %d): expected %d, got (%d, %d)", i, x, y, want, lt, eq)
+		}
+		if got := x.Eq(y) == 1; got != (want == 0) {
+			t.Fatalf("#%d: Eq(%d, %d): expected %t, got %t", i, x, y, want == 0, got)
+		}
+		if got := x.Lt(y) == 1; got != (want < 0) {
+			t.Fatalf("#%d: Lt(%d, %d): expected %t, got %t", i, x, y, want < 0, got)
+		}
+	}
+}
+
+{{with .Half}}
+func Test{{$.Name}}HiLo(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x := rand{{$.Bits}}(t)
+		hi, lo := x.Hi().words(), x.Lo().words()
+
+		var w [{{$.Words}}]uint64
+		copy(w[:], lo[:])
+		copy(w[{{.Words}}:], hi[:])
+		if z := u{{$.Bits}}(w); z != x {
+			t.Fatalf("#%d: expected %d, got %d", i, x, z)
+		}
+	}
+}
+{{end}}
+func Test{{.Name}}Bytes(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x := rand{{.Bits}}(t)
+		buf := x.FillBytes(make([]byte, {{.Bytes}}+rand.Intn(8)))
+
+		var bx big.Int
+		setInt{{.Bits}}(&bx, x)
+		if want := bx.FillBytes(make([]byte, len(buf))); string(buf) != string(want) {
+			t.Fatalf("#%d: FillBytes(%d): expected %x, got %x", i, x, want, buf)
+		}
+
+		var y {{.Name}}
+		y.SetBytes(bx.Bytes())
+		if x != y {
+			t.Fatalf("#%d: expected %d, got %d", i, x, y)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	var x {{.Name}}
+	x.FillBytes(make([]byte, {{.Bytes}}-1))
+}
+
+func Test{{.Name}}Encoding(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x := rand{{.Bits}}(t)
+
+		var bx big.Int
+		setInt{{.Bits}}(&bx, x)
+		b, err := x.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := bx.FillBytes(make([]byte, {{.Bytes}})); string(b) != string(want) {
+			t.Fatalf("#%d: MarshalBinary(%d): expected %x, got %x", i, x, want, b)
+		}
+		if be := x.Bytes{{.Bytes}}(); string(be[:]) != string(b) {
+			t.Fatalf("#%d: Bytes{{.Bytes}}(%d): expected %x, got %x", i, x, b, be)
+		}
+		var z {{.Name}}
+		if err := z.UnmarshalBinary(b); err != nil || z != x {
+			t.Fatalf("#%d: UnmarshalBinary(%x): expected (%d, nil), got (%d, %v)", i, b, x, z, err)
+		}
+
+		le := make([]byte, {{.Bytes}})
+		x.PutLE(le)
+		for j := range le {
+			if le[j] != b[len(b)-1-j] {
+				t.Fatalf("#%d: PutLE(%d): got %x", i, x, le)
+			}
+		}
+		z = {{.Name}}{}
+		z.SetBytesLE(le)
+		if z != x {
+			t.Fatalf("#%d: SetBytesLE(%x): expected %d, got %d", i, le, x, z)
+		}
+
+		z = {{.Name}}{}
+		if err := z.SetBytesChecked(append(make([]byte, 3), b...)); err != nil || z != x {
+			t.Fatalf("#%d: SetBytesChecked(%x): expected (%d, nil), got (%d, %v)", i, b, x, z, err)
+		}
+	}
+
+	z := U{{.Bits}}(42)
+	if err := z.UnmarshalBinary(make([]byte, {{.Bytes}}-1)); err == nil {
+		t.Fatal("UnmarshalBinary: expected an error")
+	}
+	if err := z.SetBytesChecked(append([]byte{1}, make([]byte, {{.Bytes}})...)); err == nil {
+		t.Fatal("SetBytesChecked: expected an error")
+	}
+	if z != U{{.Bits}}(42) {
+		t.Fatalf("expected z to be unchanged, got %d", z)
+	}
+}
+
+func Test{{.Name}}Big(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x := rand{{.Bits}}(t)
+
+		var bx big.Int
+		if got := x.ToBig(&bx); cmpInt{{.Bits}}(got, x) != 0 {
+			t.Fatalf("#%d: ToBig(%d): got %s", i, x, got)
+		}
+		z, ok := FromBig{{.Bits}}(&bx)
+		if !ok || z != x {
+			t.Fatalf("#%d: FromBig{{.Bits}}(%s): expected (%d, true), got (%d, %t)", i, &bx, x, z, ok)
+		}
+
+		// Out of range values are reduced modulo 2^{{.Bits}}.
+		var bz big.Int
+		bz.Lsh(big.NewInt(1), {{.Bits}})
+		bz.Add(&bz, &bx)
+		if z, ok := FromBig{{.Bits}}(&bz); ok || z != x {
+			t.Fatalf("#%d: FromBig{{.Bits}}(%s): expected (%d, false), got (%d, %t)", i, &bz, x, z, ok)
+		}
+		bz.Neg(&bx)
+		if z, ok := FromBig{{.Bits}}(&bz); (ok && x != {{.Name}}{}) || z != ({{.Name}}{}).Sub(x) {
+			t.Fatalf("#%d: FromBig{{.Bits}}(%s): expected (%d, false), got (%d, %t)", i, &bz, ({{.Name}}{}).Sub(x), z, ok)
+		}
+	}
+}
+
+func Test{{.Name}}Format(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		x := rand{{.Bits}}(t)
+
+		var bx big.Int
+		setInt{{.Bits}}(&bx, x)
+		for _, format := range []string{
+			"%d", "%x", "%X", "%#x", "%o", "%O", "%b", "%s", "%v",
+			"%130d", "%-130x", "%0130X", "%.140d", "%+d",
+		} {
+			want := fmt.Sprintf(format, &bx)
+			got := fmt.Sprintf(format, x)
+			if got != want {
+				t.Fatalf("#%d: %q: expected %q, got %q", i, format, want, got)
+			}
+		}
+	}
+}
+
+func Test{{.Name}}Text(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		x := rand{{.Bits}}(t)
+
+		var bx big.Int
+		setInt{{.Bits}}(&bx, x)
+		for _, base := range []int{2, 8, 10, 16, 36, 62} {
+			s := x.Text(base)
+			if want := bx.Text(base); s != want {
+				t.Fatalf("#%d: Text(%d): expected %q, got %q", i, base, want, s)
+			}
+			z, err := Parse{{.Name}}(s, base)
+			if err != nil {
+				t.Fatalf("#%d: Parse{{.Name}}(%q, %d): %v", i, s, base, err)
+			}
+			if z != x {
+				t.Fatalf("#%d: Parse{{.Name}}(%q, %d): expected %d, got %d", i, s, base, x, z)
+			}
+		}
+
+		b, err := json.Marshal(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := strconv.Quote(x.String()); string(b) != want {
+			t.Fatalf("#%d: expected %s, got %s", i, want, b)
+		}
+		var z {{.Name}}
+		if err := json.Unmarshal(b, &z); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if z != x {
+			t.Fatalf("#%d: expected %d, got %d", i, x, z)
+		}
+		if err := z.UnmarshalText([]byte("0x" + x.Text(16))); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if z != x {
+			t.Fatalf("#%d: expected %d, got %d", i, x, z)
+		}
+	}
+
+	z := U{{.Bits}}(42)
+	for _, s := range []string{"", "-1", "0x", "1.5", "1" + new(big.Int).Set(big{{.Bits}}Mask).String()} {
+		if err := z.SetString(s, 0); err == nil {
+			t.Fatalf("%q: expected an error", s)
+		}
+		if z != U{{.Bits}}(42) {
+			t.Fatalf("%q: SetString modified z", s)
+		}
+	}
+	_, err := Parse{{.Name}}(big{{.Bits}}Mask.String()+"0", 10)
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Fatalf("expected %v, got %v", strconv.ErrRange, err)
+	}
+}
+
+func Test{{.Name}}Scan(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		x := rand{{.Bits}}(t)
+		for _, tc := range []struct {
+			format, verb string
+		}{
+			{"%d", "%d"},
+			{"%x", "%x"},
+			{"%X", "%X"},
+			{"%o", "%o"},
+			{"%b", "%b"},
+			{"%#x", "%v"},
+			{"%d", "%s"},
+		} {
+			s := fmt.Sprintf(tc.format, x)
+			var z {{.Name}}
+			if _, err := fmt.Sscanf(s, tc.verb, &z); err != nil {
+				t.Fatalf("#%d: Sscanf(%q, %q): %v", i, s, tc.verb, err)
+			}
+			if z != x {
+				t.Fatalf("#%d: Sscanf(%q, %q): expected %d, got %d", i, s, tc.verb, x, z)
+			}
+		}
+	}
+}
+
+var Sink{{.Bits}} {{.Name}}
+
+func Benchmark{{.Name}}Mul(b *testing.B) {
+	x, y := rand{{.Bits}}(b), rand{{.Bits}}(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink{{.Bits}} = x.Mul(y)
+	}
+}
+
+func Benchmark{{.Name}}QuoRem(b *testing.B) {
+	x := rand{{.Bits}}(b).Or(U{{.Bits}}(1).Lsh({{.Bits}} - 1))
+	y := rand{{.Bits}}(b).Rsh({{.Bits}} / 2).Or(U{{.Bits}}(1).Lsh({{.Bits}} / 3))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink{{.Bits}}, Sink{{.Bits}} = x.QuoRem(y)
+	}
+}
+
+func Benchmark{{.Name}}MulMod(b *testing.B) {
+	x, y, m := rand{{.Bits}}(b), rand{{.Bits}}(b), rand{{.Bits}}(b).Or(U{{.Bits}}(1).Lsh({{.Bits}} - 1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink{{.Bits}} = x.MulMod(y, m)
+	}
+}
+
+func Benchmark{{.Name}}ExpCT(b *testing.B) {
+	x, y, m := rand{{.Bits}}(b), rand{{.Bits}}(b), rand{{.Bits}}(b).Or(U{{.Bits}}(1).Lsh({{.Bits}} - 1))
+	m.u0 |= 1
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink{{.Bits}} = x.ExpCT(y, m)
+	}
+}
+`))
@@ -0,0 +1,289 @@
+package xbits
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Int256 is a signed 256-bit integer in two's complement
+// form.
+//
+// Int256 and Uint256 share the same representation, so
+// converting between them with a type conversion
+// reinterprets the bits. For example, Int256(U256(1).Lsh(255))
+// is the most negative Int256.
+type Int256 Uint256
+
+var (
+	_ fmt.Stringer  = Int256{}
+	_ fmt.Formatter = Int256{}
+)
+
+// I256 creates an Int256 from an int64.
+func I256(x int64) Int256 {
+	s := uint64(x >> 63)
+	return Int256{uint64(x), s, s, s}
+}
+
+// Abs returns the absolute value of x.
+//
+// The result is a Uint256, so the absolute value of
+// the most negative Int256 does not overflow.
+//
+// This function's execution time does not depend on its inputs.
+func (x Int256) Abs() Uint256 {
+	return Select256(x.signBit(), Uint256(x.Neg()), Uint256(x))
+}
+
+// Add returns x + y.
+//
+// This function's execution time does not depend on its inputs.
+func (x Int256) Add(y Int256) Int256 {
+	return Int256(Uint256(x).Add(Uint256(y)))
+}
+
+// AppendText appends the textual representation of x in
+// the provided base to dst and returns the extended
+// buffer.
+//
+// AppendText does not allocate if dst has enough
+// capacity. The base must be in [2, 62].
+func (x Int256) AppendText(dst []byte, base int) []byte {
+	if x.Sign() < 0 {
+		dst = append(dst, '-')
+	}
+	return x.Abs().AppendText(dst, base)
+}
+
+// Cmp compares x and y and returns
+//
+//	+1 if x > y
+//	 0 if x == y
+//	-1 if x < y
+func (x Int256) Cmp(y Int256) int {
+	// Flipping the sign bit maps [-2^255, 2^255) onto
+	// [0, 2^256) while preserving order.
+	x.u3 ^= 1 << 63
+	y.u3 ^= 1 << 63
+	return Uint256(x).Cmp(Uint256(y))
+}
+
+// Div returns the Euclidean quotient x / y.
+//
+// Div implements Euclidean division, unlike Go.
+// See DivMod for more details.
+func (x Int256) Div(y Int256) Int256 {
+	q, _ := x.DivMod(y)
+	return q
+}
+
+// DivMod returns the Euclidean quotient x / y and
+// modulus x % y.
+//
+// DivMod implements Euclidean division and modulus,
+// unlike Go:
+//
+//	q = x div y  such that
+//	m = x - y*q  with 0 <= m < |y|
+//
+// See QuoRem for truncated division and modulus.
+//
+// If the quotient overflows, as with the most negative
+// Int256 divided by -1, it wraps around.
+//
+// DivMod panics if y == 0.
+func (x Int256) DivMod(y Int256) (Int256, Int256) {
+	q, m := x.QuoRem(y)
+	if m.Sign() < 0 {
+		if y.Sign() > 0 {
+			q = q.Sub(I256(1))
+			m = m.Add(y)
+		} else {
+			q = q.Add(I256(1))
+			m = m.Sub(y)
+		}
+	}
+	return q, m
+}
+
+func (x Int256) Format(s fmt.State, ch rune) {
+	abs := x.Abs()
+	format(s, ch, "Int256", x.Sign() < 0, []uint64{abs.u0, abs.u1, abs.u2, abs.u3})
+}
+
+// Int64 returns the int64 representation of x.
+//
+// The result is undefined if x cannot be
+// represented as an int64.
+func (x Int256) Int64() int64 {
+	return int64(x.u0)
+}
+
+// IsInt64 reports whether x can be represented as
+// an int64.
+func (x Int256) IsInt64() bool {
+	return x == I256(int64(x.u0))
+}
+
+// Lsh returns x<<n.
+//
+// This function's execution time does not depend on its inputs.
+func (x Int256) Lsh(n uint) Int256 {
+	return Int256(Uint256(x).Lsh(n))
+}
+
+// Mod returns the Euclidean modulus x % y.
+//
+// Mod implements Euclidean modulus, unlike Go.
+// See DivMod for more details.
+func (x Int256) Mod(y Int256) Int256 {
+	_, m := x.DivMod(y)
+	return m
+}
+
+// Mul returns x * y.
+//
+// This function's execution time does not depend on its inputs.
+func (x Int256) Mul(y Int256) Int256 {
+	return Int256(Uint256(x).Mul(Uint256(y)))
+}
+
+// Neg returns -x.
+//
+// The negation of the most negative Int256 is itself.
+//
+// This function's execution time does not depend on its inputs.
+func (x Int256) Neg() Int256 {
+	return Int256(Uint256{}.Sub(Uint256(x)))
+}
+
+// Quo returns x / y.
+//
+// Quo implements truncated division, like Go.
+// See QuoRem for more details.
+func (x Int256) Quo(y Int256) Int256 {
+	q, _ := x.QuoRem(y)
+	return q
+}
+
+// QuoRem returns x / y and x % y.
+//
+// QuoRem implements truncated division and modulus,
+// like Go:
+//
+//	q = x/y      with the result truncated to zero
+//	r = x - y*q
+//
+// See DivMod for Euclidean division and modulus.
+//
+// If the quotient overflows, as with the most negative
+// Int256 divided by -1, it wraps around.
+//
+// QuoRem panics if y == 0.
+func (x Int256) QuoRem(y Int256) (Int256, Int256) {
+	q, r := x.Abs().QuoRem(y.Abs())
+	qn := Int256(q)
+	if x.Sign() != y.Sign() {
+		qn = qn.Neg()
+	}
+	rn := Int256(r)
+	if x.Sign() < 0 {
+		rn = rn.Neg()
+	}
+	return qn, rn
+}
+
+// Rem returns x % y.
+//
+// Rem implements truncated modulus, like Go.
+// See QuoRem for more details.
+func (x Int256) Rem(y Int256) Int256 {
+	_, r := x.QuoRem(y)
+	return r
+}
+
+// Rsh returns x>>n.
+//
+// Rsh implements an arithmetic shift, like Go.
+//
+// This function's execution time does not depend on its inputs.
+func (x Int256) Rsh(n uint) Int256 {
+	// For negative x, x>>n = ^(^x>>n).
+	mask := Uint256{}.Sub(U256(x.signBit()))
+	return Int256(Uint256(x).Xor(mask).Rsh(n).Xor(mask))
+}
+
+// SetBig sets z to x and reports whether x can be
+// represented as an Int256.
+//
+// If x cannot be represented as an Int256, z is left
+// unchanged.
+func (z *Int256) SetBig(x *big.Int) bool {
+	if x.BitLen() > 256 {
+		return false
+	}
+	var abs Uint256
+	abs.SetBytes(x.Bytes())
+	v := Int256(abs)
+	if x.Sign() < 0 {
+		v = v.Neg()
+		if v.Sign() > 0 {
+			// abs > 2^255
+			return false
+		}
+	} else if v.Sign() < 0 {
+		// abs >= 2^255
+		return false
+	}
+	*z = v
+	return true
+}
+
+// Sign returns
+//
+//	-1 if x <  0
+//	 0 if x == 0
+//	+1 if x >  0
+func (x Int256) Sign() int {
+	if x.signBit() == 1 {
+		return -1
+	}
+	if x == (Int256{}) {
+		return 0
+	}
+	return +1
+}
+
+// signBit returns 1 if x < 0 and 0 otherwise.
+func (x Int256) signBit() uint64 {
+	return x.u3 >> 63
+}
+
+func (x Int256) String() string {
+	return x.Text(10)
+}
+
+// Sub returns x - y.
+//
+// This function's execution time does not depend on its inputs.
+func (x Int256) Sub(y Int256) Int256 {
+	return Int256(Uint256(x).Sub(Uint256(y)))
+}
+
+// Text returns the textual representation of
+// x in the provided base.
+//
+// The base must be in [2, 62].
+func (x Int256) Text(base int) string {
+	var buf [257]byte
+	return string(x.AppendText(buf[:0], base))
+}
+
+// ToBig sets z to x and returns z.
+func (x Int256) ToBig(z *big.Int) *big.Int {
+	setInt(z, x.Abs())
+	if x.Sign() < 0 {
+		z.Neg(z)
+	}
+	return z
+}
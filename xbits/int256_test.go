@@ -0,0 +1,258 @@
+package xbits
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+var (
+	minInt256 = Int256(U256(1).Lsh(255))
+	maxInt256 = Int256(max256.Rsh(1))
+
+	big256 = new(big.Int).Lsh(big.NewInt(1), 256)
+	big255 = new(big.Int).Lsh(big.NewInt(1), 255)
+)
+
+// randInt256 returns a random Int256 with a random bit
+// length and sign.
+func randInt256(t testing.TB) Int256 {
+	x, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rand.Intn(2) == 0 {
+		return Int256(x).Neg()
+	}
+	return Int256(x)
+}
+
+// wrap256 reduces x into [-2^255, 2^255).
+func wrap256(x *big.Int) *big.Int {
+	x.Mod(x, big256)
+	if x.Cmp(big255) >= 0 {
+		x.Sub(x, big256)
+	}
+	return x
+}
+
+func cmpInt256(x *big.Int, y Int256) int {
+	var yy big.Int
+	return x.Cmp(y.ToBig(&yy))
+}
+
+func TestInt256Binary(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		fn   func(x, y Int256) Int256
+		big  func(z, x, y *big.Int) *big.Int
+	}{
+		{"Add", Int256.Add, (*big.Int).Add},
+		{"Sub", Int256.Sub, (*big.Int).Sub},
+		{"Mul", Int256.Mul, (*big.Int).Mul},
+	} {
+		for i := 0; i < 10_000; i++ {
+			x, y := randInt256(t), randInt256(t)
+			z := tc.fn(x, y)
+
+			var bz, bx, by big.Int
+			x.ToBig(&bx)
+			y.ToBig(&by)
+			wrap256(tc.big(&bz, &bx, &by))
+
+			if cmpInt256(&bz, z) != 0 {
+				t.Fatalf("%s #%d: expected %s, got %d", tc.name, i, bz.String(), z)
+			}
+		}
+	}
+}
+
+func TestInt256Div(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		x, y := randInt256(t), randInt256(t)
+		if y.Sign() == 0 {
+			y = I256(-1)
+		}
+
+		var bx, by, bq, br big.Int
+		x.ToBig(&bx)
+		y.ToBig(&by)
+
+		bq.QuoRem(&bx, &by, &br)
+		wrap256(&bq)
+		q, r := x.QuoRem(y)
+		if cmpInt256(&bq, q) != 0 || cmpInt256(&br, r) != 0 {
+			t.Fatalf("#%d: QuoRem(%d, %d): expected (%s, %s), got (%d, %d)",
+				i, x, y, bq.String(), br.String(), q, r)
+		}
+
+		bq.DivMod(&bx, &by, &br)
+		wrap256(&bq)
+		q, r = x.DivMod(y)
+		if cmpInt256(&bq, q) != 0 || cmpInt256(&br, r) != 0 {
+			t.Fatalf("#%d: DivMod(%d, %d): expected (%s, %s), got (%d, %d)",
+				i, x, y, bq.String(), br.String(), q, r)
+		}
+	}
+}
+
+func TestInt256DivEdge(t *testing.T) {
+	for i, tc := range []struct {
+		x, y Int256
+		q, r Int256
+		d, m Int256
+	}{
+		{I256(7), I256(2), I256(3), I256(1), I256(3), I256(1)},
+		{I256(-7), I256(2), I256(-3), I256(-1), I256(-4), I256(1)},
+		{I256(7), I256(-2), I256(-3), I256(1), I256(-3), I256(1)},
+		{I256(-7), I256(-2), I256(3), I256(-1), I256(4), I256(1)},
+		{minInt256, I256(-1), minInt256, I256(0), minInt256, I256(0)},
+		{minInt256, I256(1), minInt256, I256(0), minInt256, I256(0)},
+		{maxInt256, minInt256, I256(0), maxInt256, I256(0), maxInt256},
+		{minInt256, maxInt256, I256(-1), I256(-1), I256(-2), maxInt256.Sub(I256(1))},
+	} {
+		if q, r := tc.x.QuoRem(tc.y); q != tc.q || r != tc.r {
+			t.Fatalf("#%d: QuoRem(%d, %d): expected (%d, %d), got (%d, %d)",
+				i, tc.x, tc.y, tc.q, tc.r, q, r)
+		}
+		if d, m := tc.x.DivMod(tc.y); d != tc.d || m != tc.m {
+			t.Fatalf("#%d: DivMod(%d, %d): expected (%d, %d), got (%d, %d)",
+				i, tc.x, tc.y, tc.d, tc.m, d, m)
+		}
+	}
+}
+
+func TestInt256Unary(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		x := randInt256(t)
+		if i == 0 {
+			x = minInt256
+		}
+
+		var bx, bz big.Int
+		x.ToBig(&bx)
+
+		if got, want := x.Sign(), bx.Sign(); got != want {
+			t.Fatalf("#%d: Sign(%d): expected %d, got %d", i, x, want, got)
+		}
+		bz.Neg(&bx)
+		if z := x.Neg(); cmpInt256(wrap256(&bz), z) != 0 {
+			t.Fatalf("#%d: Neg(%d): expected %s, got %d", i, x, bz.String(), z)
+		}
+		bz.Abs(&bx)
+		if z := x.Abs(); cmpInt(&bz, z) != 0 {
+			t.Fatalf("#%d: Abs(%d): expected %s, got %d", i, x, bz.String(), z)
+		}
+
+		n := uint(rand.Intn(512))
+		bz.Rsh(&bx, n)
+		if z := x.Rsh(n); cmpInt256(&bz, z) != 0 {
+			t.Fatalf("#%d: %d>>%d: expected %s, got %d", i, x, n, bz.String(), z)
+		}
+		bz.Lsh(&bx, n)
+		if z := x.Lsh(n); cmpInt256(wrap256(&bz), z) != 0 {
+			t.Fatalf("#%d: %d<<%d: expected %s, got %d", i, x, n, bz.String(), z)
+		}
+	}
+}
+
+func TestInt256Cmp(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		x, y := randInt256(t), randInt256(t)
+		if rand.Intn(4) == 0 {
+			y = x
+		}
+
+		var bx, by big.Int
+		x.ToBig(&bx)
+		y.ToBig(&by)
+		if got, want := x.Cmp(y), bx.Cmp(&by); got != want {
+			t.Fatalf("#%d: Cmp(%d, %d): expected %d, got %d", i, x, y, want, got)
+		}
+	}
+	if minInt256.Cmp(maxInt256) != -1 {
+		t.Fatal("expected minInt256 < maxInt256")
+	}
+}
+
+func TestInt256Conv(t *testing.T) {
+	for i, x := range []int64{0, 1, -1, math.MaxInt64, math.MinInt64} {
+		z := I256(x)
+		if !z.IsInt64() || z.Int64() != x {
+			t.Fatalf("#%d: expected %d, got %d", i, x, z)
+		}
+		if got := z.String(); got != fmt.Sprint(x) {
+			t.Fatalf("#%d: expected %d, got %s", i, x, got)
+		}
+	}
+	if I256(math.MaxInt64).Add(I256(1)).IsInt64() {
+		t.Fatal("MaxInt64+1 should not be an int64")
+	}
+	if I256(math.MinInt64).Sub(I256(1)).IsInt64() {
+		t.Fatal("MinInt64-1 should not be an int64")
+	}
+
+	// Conversions to and from Uint256 reinterpret the bits.
+	if got := Uint256(I256(-1)); got != max256 {
+		t.Fatalf("expected %d, got %d", max256, got)
+	}
+	if got := Int256(max256); got != I256(-1) {
+		t.Fatalf("expected -1, got %d", got)
+	}
+
+	for i, tc := range []struct {
+		x  *big.Int
+		ok bool
+	}{
+		{big.NewInt(0), true},
+		{big.NewInt(-1), true},
+		{new(big.Int).Neg(big255), true},
+		{new(big.Int).Sub(big255, big.NewInt(1)), true},
+		{big255, false},
+		{new(big.Int).Sub(new(big.Int).Neg(big255), big.NewInt(1)), false},
+		{big256, false},
+		{new(big.Int).Neg(big256), false},
+	} {
+		z := I256(42)
+		ok := z.SetBig(tc.x)
+		if ok != tc.ok {
+			t.Fatalf("#%d: SetBig(%s): expected %t, got %t", i, tc.x, tc.ok, ok)
+		}
+		if !ok {
+			if z != I256(42) {
+				t.Fatalf("#%d: SetBig(%s) modified z", i, tc.x)
+			}
+			continue
+		}
+		if cmpInt256(tc.x, z) != 0 {
+			t.Fatalf("#%d: SetBig(%s): got %d", i, tc.x, z)
+		}
+	}
+}
+
+func TestInt256Format(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		x := randInt256(t)
+
+		var bx big.Int
+		x.ToBig(&bx)
+		for _, format := range []string{
+			"%d", "%x", "%X", "%#x", "%o", "%O", "%b", "%s", "%v",
+			"%80d", "%-80x", "%080X", "%.90d", "%+d", "% d", "%q",
+		} {
+			want := fmt.Sprintf(format, &bx)
+			got := fmt.Sprintf(format, x)
+			if format == "%q" {
+				want = fmt.Sprintf("%%!q(xbits.Int256=%s)", bx.String())
+			}
+			if got != want {
+				t.Fatalf("#%d: %q: expected %q, got %q", i, format, want, got)
+			}
+		}
+		if got, want := x.Text(36), bx.Text(36); got != want {
+			t.Fatalf("#%d: expected %q, got %q", i, want, got)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package xbits
+
+import (
+	"math/bits"
+
+	"github.com/elagergren/ctb/xbits/ct"
+)
+
+// Montgomery implements arithmetic modulo an odd Uint256
+// using Montgomery multiplication.
+//
+// Values in the Montgomery domain are represented as
+// x*R mod m, where R = 2^256. Use ToMont and FromMont to
+// convert to and from the Montgomery domain.
+//
+// Unless otherwise noted, the inputs to each method must
+// be in the Montgomery domain and in [0, m).
+//
+// A Montgomery is safe for concurrent use.
+type Montgomery struct {
+	m Uint256
+	// one is R mod m.
+	one Uint256
+	// r2 is R^2 mod m.
+	r2 Uint256
+	// minv is -m^-1 mod 2^64.
+	minv uint64
+}
+
+// NewMontgomery creates a Montgomery context for the
+// modulus m.
+//
+// NewMontgomery panics if m is even.
+//
+// This function's execution time does not depend on its
+// inputs.
+func NewMontgomery(m Uint256) *Montgomery {
+	z := new(Montgomery)
+	z.init(m)
+	return z
+}
+
+// init initializes z for the modulus m.
+func (z *Montgomery) init(m Uint256) {
+	if m.u0&1 == 0 {
+		panic("xbits: NewMontgomery: modulus must be odd")
+	}
+
+	// Compute m^-1 mod 2^64 using Newton's method. Since m is
+	// odd, m*m = 1 (mod 8), so the initial estimate is correct
+	// to three bits. Each iteration doubles the number of
+	// correct bits.
+	inv := m.u0
+	for i := 0; i < 5; i++ {
+		inv *= 2 - m.u0*inv
+	}
+
+	z.m = m
+	z.minv = -inv
+
+	// Compute R mod m and R^2 mod m by repeated doubling,
+	// which avoids division.
+	x := U256(1 ^ eq256(m, U256(1)))
+	for i := 0; i < 256; i++ {
+		x = z.double(x)
+	}
+	z.one = x
+	for i := 0; i < 256; i++ {
+		x = z.double(x)
+	}
+	z.r2 = x
+}
+
+// Modulus returns the modulus m.
+func (z *Montgomery) Modulus() Uint256 {
+	return z.m
+}
+
+// One returns 1 in the Montgomery domain.
+func (z *Montgomery) One() Uint256 {
+	return z.one
+}
+
+// ToMont converts x into the Montgomery domain.
+//
+// Unlike the other methods, x can be any Uint256.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Montgomery) ToMont(x Uint256) Uint256 {
+	return z.mul(x, z.r2)
+}
+
+// FromMont converts x out of the Montgomery domain.
+//
+// Unlike the other methods, x can be any Uint256.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Montgomery) FromMont(x Uint256) Uint256 {
+	return z.mul(x, U256(1))
+}
+
+// Add returns x + y mod m.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Montgomery) Add(x, y Uint256) Uint256 {
+	s, c := Add256(x, y, 0)
+	return z.reduceOnce(s, c)
+}
+
+// Sub returns x - y mod m.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Montgomery) Sub(x, y Uint256) Uint256 {
+	return subMod256(x, y, z.m)
+}
+
+// Mul returns x * y mod m.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Montgomery) Mul(x, y Uint256) Uint256 {
+	return z.mul(x, y)
+}
+
+// Sqr returns x^2 mod m.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Montgomery) Sqr(x Uint256) Uint256 {
+	return z.sqr(x)
+}
+
+// Exp returns x^y mod m.
+//
+// The exponent y is not in the Montgomery domain and can
+// be any Uint256.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Montgomery) Exp(x, y Uint256) Uint256 {
+	// Fixed 4-bit window.
+	var table [16]Uint256
+	table[0] = z.one
+	for i := 1; i < len(table); i++ {
+		table[i] = z.mul(table[i-1], x)
+	}
+
+	exp := [4]uint64{y.u0, y.u1, y.u2, y.u3}
+	r := z.one
+	for i := len(exp) - 1; i >= 0; i-- {
+		for j := 64 - 4; j >= 0; j -= 4 {
+			r = z.sqr(r)
+			r = z.sqr(r)
+			r = z.sqr(r)
+			r = z.sqr(r)
+			r = z.mul(r, lookup256(table[:], (exp[i]>>uint(j))&15))
+		}
+	}
+	return r
+}
+
+// lookup256 returns table[idx].
+//
+// This function's execution time does not depend on idx.
+func lookup256(table []Uint256, idx uint64) Uint256 {
+	var z Uint256
+	for i, v := range table {
+		z = Select256(ct.Equal64(uint64(i), idx), v, z)
+	}
+	return z
+}
+
+// double returns 2*x mod m for x in [0, m).
+func (z *Montgomery) double(x Uint256) Uint256 {
+	s, c := Add256(x, x, 0)
+	return z.reduceOnce(s, c)
+}
+
+// reduceOnce returns (c<<256 + x) mod m for
+// c<<256 + x in [0, 2m).
+func (z *Montgomery) reduceOnce(x Uint256, c uint64) Uint256 {
+	d, b := Sub256(x, z.m, 0)
+	// Keep x - m if there was a carry out of x or x >= m.
+	return Select256(c|(b^1), d, x)
+}
+
+// mul returns x*y*R^-1 mod m.
+//
+// If x*y < m*R, the result is fully reduced.
+//
+// mul implements the Coarsely Integrated Operand Scanning
+// (CIOS) method from Ç. K. Koç, T. Acar, and B. S. Kaliski,
+// "Analyzing and Comparing Montgomery Multiplication
+// Algorithms" (1996).
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Montgomery) mul(x, y Uint256) Uint256 {
+	xs := [4]uint64{x.u0, x.u1, x.u2, x.u3}
+	ys := [4]uint64{y.u0, y.u1, y.u2, y.u3}
+	ms := [4]uint64{z.m.u0, z.m.u1, z.m.u2, z.m.u3}
+
+	var t [6]uint64
+	for i := 0; i < 4; i++ {
+		// t += x*y[i]
+		var c uint64
+		for j := 0; j < 4; j++ {
+			c, t[j] = mulAdd128(xs[j], ys[i], t[j], c)
+		}
+		t[4], c = bits.Add64(t[4], c, 0)
+		t[5] = c
+
+		// t = (t + m*(t[0]*-m^-1 mod 2^64)) / 2^64
+		mm := t[0] * z.minv
+		c, _ = mulAdd128(mm, ms[0], t[0], 0)
+		for j := 1; j < 4; j++ {
+			c, t[j-1] = mulAdd128(mm, ms[j], t[j], c)
+		}
+		t[3], c = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + c
+	}
+	return z.reduceOnce(Uint256{t[0], t[1], t[2], t[3]}, t[4])
+}
+
+// sqr returns x^2*R^-1 mod m for x in [0, m).
+//
+// Unlike mul, sqr computes the full square before reducing
+// it, which lets it use sqr512's symmetric-product saving.
+// This is the Separated Operand Scanning (SOS) method from
+// Koç, Acar, and Kaliski.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Montgomery) sqr(x Uint256) Uint256 {
+	var t [8]uint64
+	sqr512(t[:], x)
+	return z.redc(&t)
+}
+
+// redc returns x*R^-1 mod m for x < m*R.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Montgomery) redc(x *[8]uint64) Uint256 {
+	ms := [4]uint64{z.m.u0, z.m.u1, z.m.u2, z.m.u3}
+
+	// hc is the carry out of x[i+3] into x[i+4].
+	var hc uint64
+	for i := 0; i < 4; i++ {
+		// x += m*(x[i]*-m^-1 mod 2^64)*2^(64*i), which
+		// clears x[i].
+		mm := x[i] * z.minv
+		var c uint64
+		for j := 0; j < 4; j++ {
+			c, x[i+j] = mulAdd128(mm, ms[j], x[i+j], c)
+		}
+		x[i+4], hc = bits.Add64(x[i+4], c, hc)
+	}
+	return z.reduceOnce(Uint256{x[4], x[5], x[6], x[7]}, hc)
+}
+
+// mulAdd128 returns x*y + a + c.
+func mulAdd128(x, y, a, c uint64) (z1, z0 uint64) {
+	hi, lo := bits.Mul64(x, y)
+	var cc uint64
+	lo, cc = bits.Add64(lo, a, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	return hi, lo
+}
@@ -0,0 +1,270 @@
+package xbits
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// randOdd256 returns a random odd modulus with
+// a random bit length.
+func randOdd256(t testing.TB) Uint256 {
+	max := max256.Rsh(uint(rand.Intn(256)))
+	m, err := Rand256(rng, max)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.u0 |= 1
+	return m
+}
+
+func TestMontgomeryConstants(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		m := randOdd256(t)
+		z := NewMontgomery(m)
+
+		var bm, br, br2 big.Int
+		setInt(&bm, m)
+		br.Lsh(big.NewInt(1), 256)
+		br2.Mul(&br, &br)
+		br.Mod(&br, &bm)
+		br2.Mod(&br2, &bm)
+
+		if cmpInt(&br, z.One()) != 0 {
+			t.Fatalf("#%d: R mod %d: expected %s, got %d", i, m, br.String(), z.One())
+		}
+		if cmpInt(&br2, z.r2) != 0 {
+			t.Fatalf("#%d: R^2 mod %d: expected %s, got %d", i, m, br2.String(), z.r2)
+		}
+		if m.u0*-z.minv != 1 {
+			t.Fatalf("#%d: bad -m^-1: %d", i, z.minv)
+		}
+	}
+}
+
+func TestMontgomeryMul(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		m := randOdd256(t)
+		z := NewMontgomery(m)
+
+		x, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := z.FromMont(z.Mul(z.ToMont(x), z.ToMont(y)))
+
+		var bz, bx, by, bm big.Int
+		setInt(&bx, x)
+		setInt(&by, y)
+		setInt(&bm, m)
+		bz.Mul(&bx, &by)
+		bz.Mod(&bz, &bm)
+
+		if cmpInt(&bz, got) != 0 {
+			t.Fatalf("#%d: %d*%d mod %d: expected %s, got %d",
+				i, x, y, m, bz.String(), got)
+		}
+
+		got = z.FromMont(z.Sqr(z.ToMont(x)))
+		bz.Mul(&bx, &bx)
+		bz.Mod(&bz, &bm)
+		if cmpInt(&bz, got) != 0 {
+			t.Fatalf("#%d: %d^2 mod %d: expected %s, got %d",
+				i, x, m, bz.String(), got)
+		}
+	}
+}
+
+func TestMontgomeryAddSub(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		m := randOdd256(t)
+		z := NewMontgomery(m)
+
+		x, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		xm, ym := z.ToMont(x), z.ToMont(y)
+
+		var bz, bx, by, bm big.Int
+		setInt(&bx, x)
+		setInt(&by, y)
+		setInt(&bm, m)
+
+		got := z.FromMont(z.Add(xm, ym))
+		bz.Add(&bx, &by)
+		bz.Mod(&bz, &bm)
+		if cmpInt(&bz, got) != 0 {
+			t.Fatalf("#%d: %d+%d mod %d: expected %s, got %d",
+				i, x, y, m, bz.String(), got)
+		}
+
+		got = z.FromMont(z.Sub(xm, ym))
+		bz.Sub(&bx, &by)
+		bz.Mod(&bz, &bm)
+		if cmpInt(&bz, got) != 0 {
+			t.Fatalf("#%d: %d-%d mod %d: expected %s, got %d",
+				i, x, y, m, bz.String(), got)
+		}
+	}
+}
+
+func TestMontgomeryExp(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		m := randOdd256(t)
+		z := NewMontgomery(m)
+
+		x, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := z.FromMont(z.Exp(z.ToMont(x), y))
+
+		var bz, bx, by, bm big.Int
+		setInt(&bx, x)
+		setInt(&by, y)
+		setInt(&bm, m)
+		bz.Exp(&bx, &by, &bm)
+
+		if cmpInt(&bz, got) != 0 {
+			t.Fatalf("#%d: %d^%d mod %d: expected %s, got %d",
+				i, x, y, m, bz.String(), got)
+		}
+	}
+}
+
+func TestMontgomeryEdge(t *testing.T) {
+	for i, m := range []Uint256{
+		U256(1),
+		U256(3),
+		max256,
+		max128,
+		{1, 0, 0, 1 << 63},
+	} {
+		z := NewMontgomery(m)
+		for j, x := range []Uint256{
+			U256(0),
+			U256(1),
+			U256(2),
+			max256,
+			m.Sub(U256(1)),
+		} {
+			var bz, bx, bm big.Int
+			setInt(&bx, x)
+			setInt(&bm, m)
+
+			got := z.FromMont(z.ToMont(x))
+			bz.Mod(&bx, &bm)
+			if cmpInt(&bz, got) != 0 {
+				t.Fatalf("#%d.%d: %d mod %d: expected %s, got %d",
+					i, j, x, m, bz.String(), got)
+			}
+
+			got = z.FromMont(z.Exp(z.ToMont(x), max256))
+			bz.Exp(&bx, big256Mask, &bm)
+			if cmpInt(&bz, got) != 0 {
+				t.Fatalf("#%d.%d: %d^(2^256-1) mod %d: expected %s, got %d",
+					i, j, x, m, bz.String(), got)
+			}
+		}
+	}
+}
+
+func TestNewMontgomeryEven(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	NewMontgomery(U256(4))
+}
+
+func TestMontgomeryAllocs(t *testing.T) {
+	x, y := max256.Rsh(1), max256
+	for _, m := range []Uint256{U256(3), max256, {1, 0, 0, 1 << 63}} {
+		n := testing.AllocsPerRun(10, func() {
+			z := NewMontgomery(m)
+			Sink256 = z.Exp(z.ToMont(x), y)
+		})
+		if n != 0 {
+			t.Fatalf("%d: expected 0 allocations, got %.1f", m, n)
+		}
+	}
+}
+
+func BenchmarkMontgomeryMul(b *testing.B) {
+	m, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	m.u0 |= 1
+	z := NewMontgomery(m)
+	x, err := Rand256(rng, m)
+	if err != nil {
+		b.Fatal(err)
+	}
+	y, err := Rand256(rng, m)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink256 = z.Mul(x, y)
+	}
+}
+
+func BenchmarkMontgomerySqr(b *testing.B) {
+	m, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	m.u0 |= 1
+	z := NewMontgomery(m)
+	x, err := Rand256(rng, m)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("Sqr", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Sink256 = z.Sqr(x)
+		}
+	})
+	b.Run("Mul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Sink256 = z.Mul(x, x)
+		}
+	})
+}
+
+func BenchmarkMontgomeryExp(b *testing.B) {
+	m, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	m.u0 |= 1
+	z := NewMontgomery(m)
+	x, err := Rand256(rng, m)
+	if err != nil {
+		b.Fatal(err)
+	}
+	y, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink256 = z.Exp(x, y)
+	}
+}
@@ -0,0 +1,236 @@
+package xbits
+
+// Nat256 is an unsigned 256-bit integer with a mutating
+// API in the style of math/big.
+//
+// Each method sets its receiver to the result of the
+// operation and returns the receiver, so operations can be
+// chained:
+//
+//	z.Mul(x, y).Add(z, c)
+//
+// The operands may alias the receiver and each other.
+//
+// Unlike math/big, the methods on Nat256 never allocate.
+// They use the same algorithms as the corresponding
+// methods on Uint256 and have the same timing
+// characteristics.
+//
+// The zero value for a Nat256 represents 0. A Nat256 can
+// be converted to and from a Uint256 with a type
+// conversion.
+type Nat256 Uint256
+
+// get returns x as a Uint256.
+func (x *Nat256) get() Uint256 {
+	return Uint256(*x)
+}
+
+// set sets z = x and returns z.
+func (z *Nat256) set(x Uint256) *Nat256 {
+	*z = Nat256(x)
+	return z
+}
+
+// Set sets z = x and returns z.
+func (z *Nat256) Set(x *Nat256) *Nat256 {
+	*z = *x
+	return z
+}
+
+// SetUint64 sets z = x and returns z.
+func (z *Nat256) SetUint64(x uint64) *Nat256 {
+	return z.set(U256(x))
+}
+
+// SetUint256 sets z = x and returns z.
+func (z *Nat256) SetUint256(x Uint256) *Nat256 {
+	return z.set(x)
+}
+
+// Uint256 returns x as a Uint256.
+func (x *Nat256) Uint256() Uint256 {
+	return x.get()
+}
+
+// SetBytes interprets buf as a big-endian unsigned
+// integer, sets z to that value, and returns z.
+//
+// If buf is larger than 32 bytes, SetBytes will panic.
+func (z *Nat256) SetBytes(buf []byte) *Nat256 {
+	(*Uint256)(z).SetBytes(buf)
+	return z
+}
+
+// FillBytes sets buf to x, storing it as a zero-extended
+// big-endian byte slice, and returns buf.
+//
+// If buf is smaller than 32 bytes, FillBytes will panic.
+func (x *Nat256) FillBytes(buf []byte) []byte {
+	return x.get().FillBytes(buf)
+}
+
+// Add sets z = x + y and returns z.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Nat256) Add(x, y *Nat256) *Nat256 {
+	return z.set(x.get().Add(y.get()))
+}
+
+// Sub sets z = x - y and returns z.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Nat256) Sub(x, y *Nat256) *Nat256 {
+	return z.set(x.get().Sub(y.get()))
+}
+
+// Mul sets z = x * y and returns z.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Nat256) Mul(x, y *Nat256) *Nat256 {
+	return z.set(x.get().Mul(y.get()))
+}
+
+// Quo sets z = x / y and returns z.
+//
+// Quo panics if y == 0.
+func (z *Nat256) Quo(x, y *Nat256) *Nat256 {
+	return z.set(x.get().Quo(y.get()))
+}
+
+// Rem sets z = x % y and returns z.
+//
+// Rem panics if y == 0.
+func (z *Nat256) Rem(x, y *Nat256) *Nat256 {
+	return z.set(x.get().Rem(y.get()))
+}
+
+// QuoRem sets z = x / y and r = x % y and returns the pair
+// (z, r).
+//
+// QuoRem panics if y == 0.
+func (z *Nat256) QuoRem(x, y, r *Nat256) (*Nat256, *Nat256) {
+	q, m := x.get().QuoRem(y.get())
+	z.set(q)
+	r.set(m)
+	return z, r
+}
+
+// MulMod sets z = x*y mod m and returns z.
+//
+// MulMod panics if m == 0.
+func (z *Nat256) MulMod(x, y, m *Nat256) *Nat256 {
+	return z.set(x.get().MulMod(y.get(), m.get()))
+}
+
+// Exp sets z = x**y mod m and returns z.
+//
+// See ExpCT for a constant-time version.
+func (z *Nat256) Exp(x, y, m *Nat256) *Nat256 {
+	return z.set(x.get().Exp(y.get(), m.get()))
+}
+
+// ExpCT sets z = x**y mod m and returns z.
+//
+// ExpCT panics if m == 0.
+//
+// This function's execution time does not depend on x or
+// y.
+func (z *Nat256) ExpCT(x, y, m *Nat256) *Nat256 {
+	return z.set(x.get().ExpCT(y.get(), m.get()))
+}
+
+// ModInverse sets z to the multiplicative inverse of g in
+// the ring ℤ/nℤ and returns z.
+//
+// If g and n are not relatively prime, g has no
+// multiplicative inverse in the ring ℤ/nℤ. In this case,
+// z is unchanged and the return value is nil.
+//
+// ModInverse panics if n == 0.
+func (z *Nat256) ModInverse(g, n *Nat256) *Nat256 {
+	inv, ok := modInverse(g.get(), n.get())
+	if !ok {
+		return nil
+	}
+	return z.set(inv)
+}
+
+// Sqrt sets z to ⌊√x⌋ and returns z.
+func (z *Nat256) Sqrt(x *Nat256) *Nat256 {
+	return z.set(x.get().Sqrt())
+}
+
+// GCD sets z to the greatest common divisor of x and y and
+// returns z.
+func (z *Nat256) GCD(x, y *Nat256) *Nat256 {
+	return z.set(x.get().GCD(y.get()))
+}
+
+// Lsh sets z = x << n and returns z.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Nat256) Lsh(x *Nat256, n uint) *Nat256 {
+	return z.set(x.get().Lsh(n))
+}
+
+// Rsh sets z = x >> n and returns z.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (z *Nat256) Rsh(x *Nat256, n uint) *Nat256 {
+	return z.set(x.get().Rsh(n))
+}
+
+// And sets z = x & y and returns z.
+func (z *Nat256) And(x, y *Nat256) *Nat256 {
+	return z.set(x.get().And(y.get()))
+}
+
+// Or sets z = x | y and returns z.
+func (z *Nat256) Or(x, y *Nat256) *Nat256 {
+	return z.set(x.get().Or(y.get()))
+}
+
+// Xor sets z = x ^ y and returns z.
+func (z *Nat256) Xor(x, y *Nat256) *Nat256 {
+	return z.set(x.get().Xor(y.get()))
+}
+
+// Not sets z = ^x and returns z.
+func (z *Nat256) Not(x *Nat256) *Nat256 {
+	return z.set(x.get().Xor(max256))
+}
+
+// Cmp compares x and y and returns
+//
+//	+1 if x > y
+//	 0 if x == y
+//	-1 if x < y
+func (x *Nat256) Cmp(y *Nat256) int {
+	return x.get().Cmp(y.get())
+}
+
+// Bit returns the value of bit at index i.
+//
+// The index must be >= 0.
+func (x *Nat256) Bit(i int) uint {
+	return x.get().Bit(i)
+}
+
+// BitLen returns the number of bits needed to represent x.
+func (x *Nat256) BitLen() int {
+	return x.get().BitLen()
+}
+
+// IsZero returns 1 if x == 0 and 0 otherwise.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x *Nat256) IsZero() uint64 {
+	return x.get().IsZero()
+}
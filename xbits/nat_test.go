@@ -0,0 +1,265 @@
+package xbits
+
+import "testing"
+
+// natTests are the Nat256 methods that compute a Uint256
+// from up to three operands.
+var natTests = []struct {
+	name string
+	fn   func(z, x, y, m *Nat256)
+	want func(x, y, m Uint256) Uint256
+}{
+	{
+		"Set",
+		func(z, x, _, _ *Nat256) { z.Set(x) },
+		func(x, _, _ Uint256) Uint256 { return x },
+	},
+	{
+		"SetUint64",
+		func(z, x, _, _ *Nat256) { z.SetUint64(x.u0) },
+		func(x, _, _ Uint256) Uint256 { return U256(x.u0) },
+	},
+	{
+		"SetUint256",
+		func(z, x, _, _ *Nat256) { z.SetUint256(Uint256(*x)) },
+		func(x, _, _ Uint256) Uint256 { return x },
+	},
+	{
+		"SetBytes",
+		func(z, x, _, _ *Nat256) {
+			var buf [32]byte
+			z.SetBytes(x.FillBytes(buf[:]))
+		},
+		func(x, _, _ Uint256) Uint256 { return x },
+	},
+	{
+		"Add",
+		func(z, x, y, _ *Nat256) { z.Add(x, y) },
+		func(x, y, _ Uint256) Uint256 { return x.Add(y) },
+	},
+	{
+		"Sub",
+		func(z, x, y, _ *Nat256) { z.Sub(x, y) },
+		func(x, y, _ Uint256) Uint256 { return x.Sub(y) },
+	},
+	{
+		"Mul",
+		func(z, x, y, _ *Nat256) { z.Mul(x, y) },
+		func(x, y, _ Uint256) Uint256 { return x.Mul(y) },
+	},
+	{
+		"Quo",
+		func(z, x, _, m *Nat256) { z.Quo(x, m) },
+		func(x, _, m Uint256) Uint256 { return x.Quo(m) },
+	},
+	{
+		"Rem",
+		func(z, x, _, m *Nat256) { z.Rem(x, m) },
+		func(x, _, m Uint256) Uint256 { return x.Rem(m) },
+	},
+	{
+		"QuoRem",
+		func(z, x, _, m *Nat256) {
+			var r Nat256
+			z.QuoRem(x, m, &r)
+			z.Xor(z, &r)
+		},
+		func(x, _, m Uint256) Uint256 {
+			q, r := x.QuoRem(m)
+			return q.Xor(r)
+		},
+	},
+	{
+		"MulMod",
+		func(z, x, y, m *Nat256) { z.MulMod(x, y, m) },
+		func(x, y, m Uint256) Uint256 { return x.MulMod(y, m) },
+	},
+	{
+		"Exp",
+		func(z, x, y, m *Nat256) { z.Exp(x, y, m) },
+		func(x, y, m Uint256) Uint256 { return x.Exp(y, m) },
+	},
+	{
+		"ExpCT",
+		func(z, x, y, m *Nat256) { z.ExpCT(x, y, m) },
+		func(x, y, m Uint256) Uint256 { return x.ExpCT(y, m) },
+	},
+	{
+		"ModInverse",
+		func(z, x, _, m *Nat256) {
+			if z.ModInverse(x, m) == nil {
+				z.SetUint64(0)
+			}
+		},
+		func(x, _, m Uint256) Uint256 {
+			z, ok := modInverse(x, m)
+			if !ok {
+				return Uint256{}
+			}
+			return z
+		},
+	},
+	{
+		"Sqrt",
+		func(z, x, _, _ *Nat256) { z.Sqrt(x) },
+		func(x, _, _ Uint256) Uint256 { return x.Sqrt() },
+	},
+	{
+		"GCD",
+		func(z, x, y, _ *Nat256) { z.GCD(x, y) },
+		func(x, y, _ Uint256) Uint256 { return x.GCD(y) },
+	},
+	{
+		"Lsh",
+		func(z, x, y, _ *Nat256) { z.Lsh(x, uint(y.u0%300)) },
+		func(x, y, _ Uint256) Uint256 { return x.Lsh(uint(y.u0 % 300)) },
+	},
+	{
+		"Rsh",
+		func(z, x, y, _ *Nat256) { z.Rsh(x, uint(y.u0%300)) },
+		func(x, y, _ Uint256) Uint256 { return x.Rsh(uint(y.u0 % 300)) },
+	},
+	{
+		"And",
+		func(z, x, y, _ *Nat256) { z.And(x, y) },
+		func(x, y, _ Uint256) Uint256 { return x.And(y) },
+	},
+	{
+		"Or",
+		func(z, x, y, _ *Nat256) { z.Or(x, y) },
+		func(x, y, _ Uint256) Uint256 { return x.Or(y) },
+	},
+	{
+		"Xor",
+		func(z, x, y, _ *Nat256) { z.Xor(x, y) },
+		func(x, y, _ Uint256) Uint256 { return x.Xor(y) },
+	},
+	{
+		"Not",
+		func(z, x, _, _ *Nat256) { z.Not(x) },
+		func(x, _, _ Uint256) Uint256 {
+			return Uint256{^x.u0, ^x.u1, ^x.u2, ^x.u3}
+		},
+	},
+	{
+		"Cmp",
+		func(z, x, y, _ *Nat256) { z.SetUint64(uint64(x.Cmp(y) + 1)) },
+		func(x, y, _ Uint256) Uint256 { return U256(uint64(x.Cmp(y) + 1)) },
+	},
+	{
+		"Bit",
+		func(z, x, y, _ *Nat256) { z.SetUint64(uint64(x.Bit(int(y.u0 % 300)))) },
+		func(x, y, _ Uint256) Uint256 { return U256(uint64(x.Bit(int(y.u0 % 300)))) },
+	},
+	{
+		"BitLen",
+		func(z, x, _, _ *Nat256) { z.SetUint64(uint64(x.BitLen())) },
+		func(x, _, _ Uint256) Uint256 { return U256(uint64(x.BitLen())) },
+	},
+	{
+		"IsZero",
+		func(z, x, _, _ *Nat256) { z.SetUint64(x.IsZero()) },
+		func(x, _, _ Uint256) Uint256 { return U256(x.IsZero()) },
+	},
+	{
+		"Uint256",
+		func(z, x, _, _ *Nat256) { *z = Nat256(x.Uint256()) },
+		func(x, _, _ Uint256) Uint256 { return x },
+	},
+}
+
+// randNatArgs returns random operands for natTests. m is
+// never zero and is even about half the time.
+func randNatArgs(t *testing.T) (x, y, m Uint256) {
+	x, y = randBits256(t), randBits256(t)
+	m = randBits256(t).Rsh(uint(x.u0 % 256))
+	if m.IsZero() == 1 {
+		m = U256(3)
+	}
+	return x, y, m
+}
+
+func TestNat256(t *testing.T) {
+	for _, tc := range natTests {
+		for i := 0; i < 1000; i++ {
+			x, y, m := randNatArgs(t)
+			want := tc.want(x, y, m)
+
+			nx, ny, nm := Nat256(x), Nat256(y), Nat256(m)
+			var z Nat256
+			tc.fn(&z, &nx, &ny, &nm)
+			if got := Uint256(z); got != want {
+				t.Fatalf("%s(%d, %d, %d): expected %d, got %d",
+					tc.name, x, y, m, want, got)
+			}
+			if Uint256(nx) != x || Uint256(ny) != y || Uint256(nm) != m {
+				t.Fatalf("%s: modified an operand", tc.name)
+			}
+
+			// z aliases x.
+			tc.fn(&nx, &nx, &ny, &nm)
+			if got := Uint256(nx); got != want {
+				t.Fatalf("%s(%d, %d, %d): (aliased) expected %d, got %d",
+					tc.name, x, y, m, want, got)
+			}
+		}
+	}
+}
+
+func TestNat256Chain(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		x, y, c := randBits256(t), randBits256(t), randBits256(t)
+		nx, ny, nc := Nat256(x), Nat256(y), Nat256(c)
+		var z Nat256
+		got := z.Mul(&nx, &ny).Add(&z, &nc).Rsh(&z, 3).Uint256()
+		want := x.Mul(y).Add(c).Rsh(3)
+		if got != want {
+			t.Fatalf("expected %d, got %d", want, got)
+		}
+	}
+}
+
+func TestNat256ModInverse(t *testing.T) {
+	x, n := Nat256(U256(6)), Nat256(U256(9))
+	z := Nat256(U256(42))
+	if z.ModInverse(&x, &n) != nil {
+		t.Fatal("expected nil")
+	}
+	if z != Nat256(U256(42)) {
+		t.Fatalf("expected z to be unchanged, got %d", Uint256(z))
+	}
+}
+
+func TestNat256Allocs(t *testing.T) {
+	x, y, m := randNatArgs(t)
+	m.u0 |= 1
+	for _, tc := range natTests {
+		nx, ny, nm := Nat256(x), Nat256(y), Nat256(m)
+		var z Nat256
+		n := testing.AllocsPerRun(100, func() {
+			tc.fn(&z, &nx, &ny, &nm)
+		})
+		if n != 0 {
+			t.Errorf("%s: expected 0 allocations, got %.1f", tc.name, n)
+		}
+
+		// Even moduli take different paths.
+		nm = Nat256(Uint256{m.u0&^1 | 2, m.u1, m.u2, m.u3})
+		n = testing.AllocsPerRun(100, func() {
+			tc.fn(&z, &nx, &ny, &nm)
+		})
+		if n != 0 {
+			t.Errorf("%s (even): expected 0 allocations, got %.1f", tc.name, n)
+		}
+	}
+}
+
+func BenchmarkNat256MulMod(b *testing.B) {
+	x, y, m := Nat256(max256), Nat256(max256.Rsh(1)), Nat256(max256.Rsh(3))
+	var z Nat256
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		z.MulMod(&x, &y, &m)
+	}
+	Sink256 = Uint256(z)
+}
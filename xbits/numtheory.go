@@ -0,0 +1,344 @@
+package xbits
+
+// Sqrt returns ⌊√x⌋, the largest integer z such that
+// z*z <= x.
+func (x Uint256) Sqrt() Uint256 {
+	if x.IsUint64() && x.u0 < 2 {
+		return x
+	}
+	// Newton's method, starting from z1 = 2^⌈n/2⌉ > √x
+	// where n is the bit length of x. The iteration
+	// decreases monotonically until it reaches ⌊√x⌋.
+	z1 := U256(1).Lsh(uint(x.BitLen()+1) / 2)
+	for {
+		z2 := z1.Add(x.Quo(z1)).Rsh(1)
+		if z2.Cmp(z1) >= 0 {
+			return z1
+		}
+		z1 = z2
+	}
+}
+
+// Log2 returns ⌊log2(x)⌋.
+//
+// If x == 0, Log2 returns -1.
+func (x Uint256) Log2() int {
+	return x.BitLen() - 1
+}
+
+// pow10 is 10^i for i in [0, 77].
+var pow10 = func() (t [78]Uint256) {
+	t[0] = U256(1)
+	for i := 1; i < len(t); i++ {
+		t[i] = t[i-1].Mul(U256(10))
+	}
+	return t
+}()
+
+// Log10 returns ⌊log10(x)⌋.
+//
+// If x == 0, Log10 returns -1.
+func (x Uint256) Log10() int {
+	n := x.BitLen()
+	if n == 0 {
+		return -1
+	}
+	// 1233/4096 is slightly larger than log10(2), so t is
+	// either ⌊log10(x)⌋ or one too large.
+	t := n * 1233 >> 12
+	if x.Cmp(pow10[t]) < 0 {
+		t--
+	}
+	return t
+}
+
+// GCD returns the greatest common divisor of x and y.
+//
+// GCD(x, 0) = GCD(0, x) = x.
+func (x Uint256) GCD(y Uint256) Uint256 {
+	if x.IsZero() == 1 {
+		return y
+	}
+	if y.IsZero() == 1 {
+		return x
+	}
+	// Binary GCD.
+	xz, yz := x.TrailingZeros(), y.TrailingZeros()
+	k := xz
+	if yz < k {
+		k = yz
+	}
+	x = x.Rsh(uint(xz))
+	for {
+		// x and y are odd after the shifts.
+		y = y.Rsh(uint(y.TrailingZeros()))
+		if x.Cmp(y) > 0 {
+			x, y = y, x
+		}
+		y = y.Sub(x)
+		if y.IsZero() == 1 {
+			return x.Lsh(uint(k))
+		}
+	}
+}
+
+// ExtendedGCD returns the greatest common divisor g of x
+// and y and the Bézout coefficients a and b such that
+//
+//	a*x + b*y = g
+//
+// Unless x or y is zero, |a| <= y/(2g) and |b| <= x/(2g),
+// so a and b always fit in an Int256.
+//
+// If x == y == 0, ExtendedGCD returns (0, 0, 0).
+func (x Uint256) ExtendedGCD(y Uint256) (g Uint256, a, b Int256) {
+	if x.IsZero()&y.IsZero() == 1 {
+		return Uint256{}, Int256{}, Int256{}
+	}
+	// The extended Euclidean algorithm. The signs of the
+	// coefficients alternate, so only track their absolute
+	// values, which never overflow.
+	//
+	//    s_i = (-1)^i * s0
+	//    t_i = (-1)^(i+1) * t0
+	r0, r1 := x, y
+	s0, s1 := U256(1), Uint256{}
+	t0, t1 := Uint256{}, U256(1)
+	odd := false
+	for r1.IsZero() == 0 {
+		q, r := r0.QuoRem(r1)
+		r0, r1 = r1, r
+		s0, s1 = s1, s0.Add(q.Mul(s1))
+		t0, t1 = t1, t0.Add(q.Mul(t1))
+		odd = !odd
+	}
+	a, b = Int256(s0), Int256(t0)
+	if odd {
+		a = a.Neg()
+	} else {
+		b = b.Neg()
+	}
+	return r0, a, b
+}
+
+// LCM returns the least common multiple of x and y and
+// reports whether it did not overflow.
+//
+// LCM(x, 0) = LCM(0, x) = 0.
+func (x Uint256) LCM(y Uint256) (Uint256, bool) {
+	if x.IsZero()|y.IsZero() == 1 {
+		return Uint256{}, true
+	}
+	return x.Quo(x.GCD(y)).MulChecked(y)
+}
+
+// jacobi returns the Jacobi symbol (x/y).
+//
+// y must be odd.
+func jacobi(x, y Uint256) int {
+	// Borrowed from math/big.
+	j := 1
+	for {
+		if y == U256(1) {
+			return j
+		}
+		if x.IsZero() == 1 {
+			return 0
+		}
+		x = x.Rem(y)
+		if x.IsZero() == 1 {
+			return 0
+		}
+		// Handle factors of 2 in x.
+		s := uint(x.TrailingZeros())
+		if s&1 != 0 {
+			if m := y.u0 & 7; m == 3 || m == 5 {
+				j = -j
+			}
+		}
+		c := x.Rsh(s)
+		// Swap numerator and denominator.
+		if y.u0&3 == 3 && c.u0&3 == 3 {
+			j = -j
+		}
+		x, y = y, c
+	}
+}
+
+const (
+	// primeBitMask records the primes < 64.
+	primeBitMask uint64 = 1<<2 | 1<<3 | 1<<5 | 1<<7 |
+		1<<11 | 1<<13 | 1<<17 | 1<<19 | 1<<23 | 1<<29 | 1<<31 |
+		1<<37 | 1<<41 | 1<<43 | 1<<47 | 1<<53 | 1<<59 | 1<<61
+
+	primesA = 3 * 5 * 7 * 11 * 13 * 17 * 19 * 23 * 37
+	primesB = 29 * 31 * 41 * 43 * 47 * 53
+)
+
+// ProbablyPrime reports whether x is probably prime,
+// applying the Miller-Rabin test with n pseudorandomly
+// chosen bases as well as a Baillie-PSW test.
+//
+// Like big.Int.ProbablyPrime, ProbablyPrime is 100%
+// accurate for inputs less than 2^64, and the probability
+// that it returns true for a randomly chosen non-prime is
+// at most 4^-n. ProbablyPrime is not suitable for
+// judging primes that an adversary may have crafted to
+// fool the test.
+//
+// ProbablyPrime panics if n < 0.
+func (x Uint256) ProbablyPrime(n int) bool {
+	if n < 0 {
+		panic("xbits: negative n for ProbablyPrime")
+	}
+	if x.IsUint64() && x.u0 < 64 {
+		return primeBitMask&(1<<x.u0) != 0
+	}
+	if x.u0&1 == 0 {
+		return false // x is even
+	}
+
+	rA := x.Rem(U256(primesA)).u0
+	rB := x.Rem(U256(primesB)).u0
+	if rA%3 == 0 || rA%5 == 0 || rA%7 == 0 || rA%11 == 0 ||
+		rA%13 == 0 || rA%17 == 0 || rA%19 == 0 || rA%23 == 0 ||
+		rA%37 == 0 || rB%29 == 0 || rB%31 == 0 || rB%41 == 0 ||
+		rB%43 == 0 || rB%47 == 0 || rB%53 == 0 {
+		return false
+	}
+
+	m := NewMontgomery(x)
+	return m.millerRabin(n+1, true) && m.lucas()
+}
+
+// millerRabin reports whether the modulus passes reps
+// Miller-Rabin tests. If force2 is true, the first base
+// is 2. The other bases are pseudorandom.
+//
+// The modulus must be odd and greater than 3.
+func (m *Montgomery) millerRabin(reps int, force2 bool) bool {
+	x := m.m
+	nm1 := x.Sub(U256(1))
+	k := uint(nm1.TrailingZeros())
+	q := nm1.Rsh(k)
+	nm3 := nm1.Sub(U256(2))
+	one := m.one
+	mnm1 := m.Sub(Uint256{}, one) // -1
+
+	// Choose bases deterministically so the result only
+	// depends on x.
+	seed := x.Bytes32()
+	rand := NewDRBG(seed[:])
+
+NextRandom:
+	for i := 0; i < reps; i++ {
+		var a Uint256
+		if i == 0 && force2 {
+			a = U256(2)
+		} else {
+			// a in [2, x-2].
+			var err error
+			a, err = RandModReduced(rand, nm3)
+			if err != nil {
+				panic(err)
+			}
+			a = a.Add(U256(2))
+		}
+		y := m.Exp(m.ToMont(a), q)
+		if y == one || y == mnm1 {
+			continue
+		}
+		for j := uint(1); j < k; j++ {
+			y = m.Sqr(y)
+			if y == mnm1 {
+				continue NextRandom
+			}
+			if y == one {
+				return false
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// lucas reports whether the modulus is a strong Lucas
+// probable prime, using the "almost extra strong" test
+// with the Baillie-OEIS "method C" parameters, as in
+// math/big.
+//
+// The modulus must be odd and greater than 3.
+func (m *Montgomery) lucas() bool {
+	n := m.m
+
+	// Try increasing P ≥ 3 such that D = P² - 4 (so Q = 1)
+	// until Jacobi(D, n) = -1.
+	p := uint64(3)
+	for ; ; p++ {
+		if p > 10000 {
+			// This is widely believed to be impossible.
+			panic("xbits: internal error: cannot find (D/n) = -1 for " + n.String())
+		}
+		j := jacobi(U256(p*p-4), n)
+		if j == -1 {
+			break
+		}
+		if j == 0 {
+			// D = (p-2)(p+2) shares a prime factor with n,
+			// which must be p+2. n is prime iff n == p+2.
+			return n == U256(p+2)
+		}
+		if p == 40 {
+			// Jacobi(D, n) is never -1 if n is a square.
+			if r := n.Sqrt(); r.Sqr() == n {
+				return false
+			}
+		}
+	}
+
+	// n+1 = s*2^r for odd s. n is not 2^256-1 since 3
+	// divides 2^256-1, so n+1 does not overflow.
+	s := n.Add(U256(1))
+	r := s.TrailingZeros()
+	s = s.Rsh(uint(r))
+
+	// Compute the Lucas sequence V(s) in the Montgomery
+	// domain using
+	//
+	//    V(2k)   = V(k)² - 2
+	//    V(2k+1) = V(k)V(k+1) - P
+	mp := m.ToMont(U256(p))
+	two := m.ToMont(U256(2))
+	nm2 := m.Sub(Uint256{}, two) // -2
+	vk, vk1 := two, mp
+	for i := s.BitLen(); i >= 0; i-- {
+		if s.Bit(i) != 0 {
+			vk = m.Sub(m.Mul(vk, vk1), mp)
+			vk1 = m.Sub(m.Sqr(vk1), two)
+		} else {
+			vk1 = m.Sub(m.Mul(vk, vk1), mp)
+			vk = m.Sub(m.Sqr(vk), two)
+		}
+	}
+
+	// Check V(s) ≡ ±2 and U(s) ≡ 0 (mod n), where
+	// U(s) = D⁻¹(2V(s+1) - PV(s)).
+	if vk == two || vk == nm2 {
+		if m.Mul(mp, vk) == m.Add(vk1, vk1) {
+			return true
+		}
+	}
+
+	// Check V(2^t s) ≡ 0 (mod n) for some 0 ≤ t < r-1.
+	for t := 0; t < r-1; t++ {
+		if vk.IsZero() == 1 {
+			return true
+		}
+		// V = 2 is a fixed point of V(2k) = V(k)² - 2.
+		if vk == two {
+			return false
+		}
+		vk = m.Sub(m.Sqr(vk), two)
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+package xbits
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSqrt256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x := randBits256(t)
+		switch i {
+		case 0:
+			x = Uint256{}
+		case 1:
+			x = max256
+		case 2:
+			x = U256(1)
+		}
+		var bx, want big.Int
+		want.Sqrt(x.ToBig(&bx))
+		if got := x.Sqrt(); cmpInt(&want, got) != 0 {
+			t.Fatalf("#%d: Sqrt(%d): expected %s, got %d", i, x, &want, got)
+		}
+	}
+
+	// Perfect squares and their neighbors.
+	for i := 0; i < 1_000; i++ {
+		r := randBits256(t).Rsh(128)
+		sq := r.Mul(r)
+		if got := sq.Sqrt(); got != r {
+			t.Fatalf("#%d: Sqrt(%d): expected %d, got %d", i, sq, r, got)
+		}
+		if sq.IsZero() == 0 {
+			if got, want := sq.Sub(U256(1)).Sqrt(), r.Sub(U256(1)); got != want {
+				t.Fatalf("#%d: Sqrt(%d-1): expected %d, got %d", i, sq, want, got)
+			}
+		}
+	}
+}
+
+func TestLog256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x := randBits256(t)
+		if i < len(pow10) {
+			x = pow10[i]
+			if i%2 == 1 {
+				x = x.Sub(U256(1))
+			}
+		}
+		if x.IsZero() == 1 {
+			continue
+		}
+
+		var bx big.Int
+		x.ToBig(&bx)
+		if got, want := x.Log2(), bx.BitLen()-1; got != want {
+			t.Fatalf("#%d: Log2(%d): expected %d, got %d", i, x, want, got)
+		}
+		if got, want := x.Log10(), len(bx.String())-1; got != want {
+			t.Fatalf("#%d: Log10(%d): expected %d, got %d", i, x, want, got)
+		}
+	}
+	if got := max256.Log10(); got != 77 {
+		t.Fatalf("Log10(%d): expected 77, got %d", max256, got)
+	}
+	if (Uint256{}).Log2() != -1 || (Uint256{}).Log10() != -1 {
+		t.Fatal("expected -1 for log of zero")
+	}
+}
+
+func TestGCD256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x, y := randBits256(t), randBits256(t)
+		switch i {
+		case 0:
+			x = Uint256{}
+		case 1:
+			y = Uint256{}
+		case 2:
+			x, y = Uint256{}, Uint256{}
+		case 3:
+			y = x
+		case 4:
+			x, y = max256, U256(1)
+		}
+		if i%3 == 0 {
+			// Force a large common factor.
+			c := randBits256(t).Rsh(128)
+			x, y = x.Rsh(128).Mul(c), y.Rsh(128).Mul(c)
+		}
+
+		var bx, by, bg, ba, bb big.Int
+		x.ToBig(&bx)
+		y.ToBig(&by)
+		bg.GCD(nil, nil, &bx, &by)
+
+		if got := x.GCD(y); cmpInt(&bg, got) != 0 {
+			t.Fatalf("#%d: GCD(%d, %d): expected %s, got %d", i, x, y, &bg, got)
+		}
+
+		g, a, b := x.ExtendedGCD(y)
+		if cmpInt(&bg, g) != 0 {
+			t.Fatalf("#%d: ExtendedGCD(%d, %d): expected %s, got %d", i, x, y, &bg, g)
+		}
+		// Check a*x + b*y = g.
+		a.ToBig(&ba)
+		b.ToBig(&bb)
+		ba.Mul(&ba, &bx)
+		bb.Mul(&bb, &by)
+		if ba.Add(&ba, &bb).Cmp(&bg) != 0 {
+			t.Fatalf("#%d: ExtendedGCD(%d, %d): %d*x + %d*y = %s, expected %s",
+				i, x, y, a, b, &ba, &bg)
+		}
+
+		var want big.Int
+		if bg.Sign() != 0 {
+			want.Mul(&bx, &by)
+			want.Quo(&want, &bg)
+		}
+		l, ok := x.LCM(y)
+		if wantOK := want.BitLen() <= 256; ok != wantOK {
+			t.Fatalf("#%d: LCM(%d, %d): expected ok = %t", i, x, y, wantOK)
+		}
+		if ok && cmpInt(&want, l) != 0 {
+			t.Fatalf("#%d: LCM(%d, %d): expected %s, got %d", i, x, y, &want, l)
+		}
+	}
+}
+
+func TestJacobi256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x, y := randBits256(t), randBits256(t).Or(U256(1))
+		var bx, by big.Int
+		x.ToBig(&bx)
+		y.ToBig(&by)
+		if got, want := jacobi(x, y), big.Jacobi(&bx, &by); got != want {
+			t.Fatalf("#%d: jacobi(%d, %d): expected %d, got %d", i, x, y, want, got)
+		}
+	}
+}
+
+func TestProbablyPrime256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x := randBits256(t)
+		if i < 10_000/4 {
+			x = U256(uint64(i))
+		}
+		var bx big.Int
+		x.ToBig(&bx)
+		if got, want := x.ProbablyPrime(0), bx.ProbablyPrime(0); got != want {
+			t.Fatalf("#%d: ProbablyPrime(%d): expected %t, got %t", i, x, want, got)
+		}
+	}
+
+	for _, s := range []string{
+		// The built-in field moduli.
+		"115792089210356248762697446949407573530086143415290314195533631308867097853951",
+		"115792089210356248762697446949407573529996955224135760342422259061068512044369",
+		"115792089237316195423570985008687907853269984665640564039457584007908834671663",
+		"115792089237316195423570985008687907852837564279074904382605163141518161494337",
+		// The largest prime less than 2^256.
+		"115792089237316195423570985008687907853269984665640564039457584007913129639747",
+		// 2^255 - 19
+		"57896044618658097711785492504343953926634992332820282019728792003956564819949",
+		// 2^127 - 1
+		"170141183460469231731687303715884105727",
+	} {
+		x, err := ParseUint256(s, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !x.ProbablyPrime(20) {
+			t.Fatalf("%s: expected prime", s)
+		}
+	}
+
+	m127 := U256(1).Lsh(127).Sub(U256(1))
+	m61 := U256(1).Lsh(61).Sub(U256(1))
+	for _, x := range []Uint256{
+		// Strong pseudoprimes to several bases.
+		U256(3215031751),
+		U256(3825123056546413051),
+		// Carmichael numbers.
+		U256(561),
+		U256(9999109081),
+		// Products of large primes.
+		m127.Mul(m127),
+		m127.Mul(m61),
+	} {
+		if x.ProbablyPrime(20) {
+			t.Fatalf("%d: expected composite", x)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	U256(7).ProbablyPrime(-1)
+}
+
+func BenchmarkProbablyPrime256(b *testing.B) {
+	// The P-256 prime.
+	x := Uint256{0xffffffffffffffff, 0x00000000ffffffff, 0, 0xffffffff00000001}
+	var bx big.Int
+	x.ToBig(&bx)
+	b.Run("xbits", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.ProbablyPrime(20)
+		}
+	})
+	b.Run("big", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bx.ProbablyPrime(20)
+		}
+	})
+}
+
+func BenchmarkGCD256(b *testing.B) {
+	x, y := randBits256(b), randBits256(b)
+	for i := 0; i < b.N; i++ {
+		Sink256 = x.GCD(y)
+	}
+}
@@ -0,0 +1,109 @@
+package xbits
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"io"
+)
+
+// RandBits returns a uniform random Uint256 in [0, 2^n).
+//
+// RandBits reads exactly (n+7)/8 bytes from rand. It
+// panics if n is not in [0, 256].
+//
+// This function's execution time does not depend on the
+// random bytes.
+func RandBits(rand io.Reader, n int) (Uint256, error) {
+	if n < 0 || n > 256 {
+		panic("xbits: RandBits: invalid bit count")
+	}
+	var buf [32]byte
+	b := buf[:(n+7)/8]
+	if _, err := io.ReadFull(rand, b); err != nil {
+		return Uint256{}, err
+	}
+	var x Uint256
+	x.SetBytes(b)
+	return x.And(max256.Rsh(uint(256 - n))), nil
+}
+
+// RandModReduced returns a random Uint256 in [0, max).
+//
+// Unlike Rand256, RandModReduced does not use rejection
+// sampling. It reads exactly (max.BitLen()+64+7)/8 bytes
+// from rand and reduces them modulo max, so the number of
+// bytes read only depends on the bit length of max. The
+// result is biased by at most 2^-64.
+//
+// RandModReduced panics if max == 0.
+//
+// This function's execution time does not depend on max,
+// except for its bit length, or on the random bytes.
+func RandModReduced(rand io.Reader, max Uint256) (Uint256, error) {
+	if max.IsZero() == 1 {
+		panic("xbits: argument to RandModReduced is 0")
+	}
+	var buf [40]byte
+	b := buf[:(max.BitLen()+64+7)/8]
+	if _, err := io.ReadFull(rand, b); err != nil {
+		return Uint256{}, err
+	}
+	var w [5]uint64
+	setBytes(w[:], b, "RandModReduced")
+	return quoRemCT(nil, w[:], max), nil
+}
+
+// RandRange returns a random Uint256 in [lo, hi).
+//
+// RandRange is like RandModReduced and reads the same
+// number of bytes as RandModReduced(rand, hi-lo).
+//
+// RandRange panics if lo >= hi.
+//
+// This function's execution time does not depend on lo or
+// hi, except for the bit length of hi-lo, or on the random
+// bytes.
+func RandRange(rand io.Reader, lo, hi Uint256) (Uint256, error) {
+	if lo.Lt(hi) != 1 {
+		panic("xbits: RandRange: empty range")
+	}
+	x, err := RandModReduced(rand, hi.Sub(lo))
+	if err != nil {
+		return Uint256{}, err
+	}
+	return lo.Add(x), nil
+}
+
+// NewDRBG returns a deterministic random bit generator
+// seeded with seed.
+//
+// The generator is AES-256 in counter mode with a zero IV,
+// keyed with the SHA-256 hash of seed. The same seed always
+// produces the same stream, which makes it suitable for
+// reproducible tests. Do not use it to generate secrets
+// unless seed has at least 256 bits of entropy.
+//
+// The returned Reader never returns an error. It is not
+// safe for concurrent use.
+func NewDRBG(seed []byte) io.Reader {
+	key := sha256.Sum256(seed)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		panic(err)
+	}
+	iv := make([]byte, aes.BlockSize)
+	return &drbg{s: cipher.NewCTR(block, iv)}
+}
+
+type drbg struct {
+	s cipher.Stream
+}
+
+func (d *drbg) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	d.s.XORKeyStream(p, p)
+	return len(p), nil
+}
@@ -0,0 +1,201 @@
+package xbits
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// countReader counts the bytes read from r.
+type countReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestDRBG(t *testing.T) {
+	read := func(seed string, n int) []byte {
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(NewDRBG([]byte(seed)), buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf
+	}
+	a, b := read("seed", 1000), read("seed", 1000)
+	if !bytes.Equal(a, b) {
+		t.Fatal("same seed produced different streams")
+	}
+	if c := read("seed2", 1000); bytes.Equal(a, c) {
+		t.Fatal("different seeds produced the same stream")
+	}
+
+	// The stream does not depend on how it is read.
+	r := NewDRBG([]byte("seed"))
+	var c []byte
+	for n := 1; len(c) < len(a); n++ {
+		buf := make([]byte, n)
+		r.Read(buf)
+		c = append(c, buf...)
+	}
+	if !bytes.Equal(a, c[:len(a)]) {
+		t.Fatal("stream depends on read sizes")
+	}
+}
+
+func TestRandBits(t *testing.T) {
+	r := &countReader{r: NewDRBG([]byte("RandBits"))}
+	for n := 0; n <= 256; n++ {
+		var or Uint256
+		for i := 0; i < 100; i++ {
+			r.n = 0
+			x, err := RandBits(r, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if x.BitLen() > n {
+				t.Fatalf("%d: %d has %d bits", n, x, x.BitLen())
+			}
+			if want := (n + 7) / 8; r.n != want {
+				t.Fatalf("%d: expected to read %d bytes, read %d", n, want, r.n)
+			}
+			or = or.Or(x)
+		}
+		// Every bit is set at least once with overwhelming
+		// probability.
+		if or.BitLen() != n || or.OnesCount() != n {
+			t.Fatalf("%d: bits never set: %b", n, or)
+		}
+	}
+}
+
+func TestRandModReduced(t *testing.T) {
+	r := &countReader{r: NewDRBG([]byte("RandModReduced"))}
+	for i := 0; i < 10_000; i++ {
+		max := randBits256(t)
+		if max.IsZero() == 1 {
+			max = U256(1)
+		}
+		r.n = 0
+		x, err := RandModReduced(r, max)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if x.Cmp(max) >= 0 {
+			t.Fatalf("#%d: %d >= %d", i, x, max)
+		}
+		if want := (max.BitLen() + 64 + 7) / 8; r.n != want {
+			t.Fatalf("#%d: expected to read %d bytes, read %d", i, want, r.n)
+		}
+	}
+
+	// Each value in [0, 10) should appear about 1/10 of the
+	// time.
+	var counts [10]int
+	const n = 100_000
+	for i := 0; i < n; i++ {
+		x, err := RandModReduced(r, U256(10))
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[x.Uint64()]++
+	}
+	for v, c := range counts {
+		if c < n/10*9/10 || c > n/10*11/10 {
+			t.Fatalf("%d: unexpected count %d", v, c)
+		}
+	}
+}
+
+func TestRandRange(t *testing.T) {
+	r := NewDRBG([]byte("RandRange"))
+	for i := 0; i < 10_000; i++ {
+		lo, hi := randBits256(t), randBits256(t)
+		if lo.Cmp(hi) > 0 {
+			lo, hi = hi, lo
+		}
+		if lo == hi {
+			hi = hi.Add(U256(1))
+			if hi.IsZero() == 1 {
+				lo = lo.Sub(U256(1))
+				hi = max256
+			}
+		}
+		x, err := RandRange(r, lo, hi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if x.Cmp(lo) < 0 || x.Cmp(hi) >= 0 {
+			t.Fatalf("#%d: %d not in [%d, %d)", i, x, lo, hi)
+		}
+	}
+
+	// Deterministic with a seeded DRBG.
+	lo, hi := U256(1000), max256
+	x, _ := RandRange(NewDRBG([]byte("seed")), lo, hi)
+	y, _ := RandRange(NewDRBG([]byte("seed")), lo, hi)
+	if x != y {
+		t.Fatalf("expected %d, got %d", x, y)
+	}
+}
+
+func TestRandError(t *testing.T) {
+	r := bytes.NewReader(make([]byte, 4))
+	if _, err := RandBits(r, 64); err == nil {
+		t.Fatal("RandBits: expected an error")
+	}
+	r = bytes.NewReader(make([]byte, 4))
+	if _, err := RandModReduced(r, max256); err == nil {
+		t.Fatal("RandModReduced: expected an error")
+	}
+	r = bytes.NewReader(make([]byte, 4))
+	if _, err := RandRange(r, U256(1), max256); err == nil {
+		t.Fatal("RandRange: expected an error")
+	}
+}
+
+func TestRandPanic(t *testing.T) {
+	r := NewDRBG(nil)
+	for _, tc := range []struct {
+		name string
+		fn   func()
+		msg  string
+	}{
+		{"RandBits", func() { RandBits(r, 257) }, "xbits: RandBits: invalid bit count"},
+		{"RandBits", func() { RandBits(r, -1) }, "xbits: RandBits: invalid bit count"},
+		{"RandModReduced", func() { RandModReduced(r, Uint256{}) }, "xbits: argument to RandModReduced is 0"},
+		{"RandRange", func() { RandRange(r, U256(2), U256(2)) }, "xbits: RandRange: empty range"},
+		{"RandRange", func() { RandRange(r, U256(3), U256(2)) }, "xbits: RandRange: empty range"},
+	} {
+		func() {
+			defer func() {
+				if got := recover(); got != tc.msg {
+					t.Fatalf("%s: expected panic %q, got %v", tc.name, tc.msg, got)
+				}
+			}()
+			tc.fn()
+		}()
+	}
+}
+
+func BenchmarkRand256(b *testing.B) {
+	max := max256.Rsh(1).Add(U256(1))
+	for _, tc := range []struct {
+		name string
+		fn   func(io.Reader, Uint256) (Uint256, error)
+	}{
+		{"Rand256", Rand256},
+		{"RandModReduced", RandModReduced},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			r := NewDRBG(nil)
+			for i := 0; i < b.N; i++ {
+				Sink256, _ = tc.fn(r, max)
+			}
+		})
+	}
+}
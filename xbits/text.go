@@ -0,0 +1,417 @@
+package xbits
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+)
+
+var _ fmt.Scanner = (*Uint256)(nil)
+
+// ParseUint256 interprets a string s in the given base
+// (0, 2 to 62) and returns the corresponding value.
+//
+// For bases <= 36, lower and upper case letters are
+// considered the same: the letters 'a' to 'z' and 'A' to
+// 'Z' represent digit values 10 to 35. For bases > 36, the
+// upper case letters 'A' to 'Z' represent the digit values
+// 36 to 61.
+//
+// If the base argument is 0, the true base is implied by
+// the string's prefix: 2 for "0b", 8 for "0" or "0o", 16
+// for "0x", and 10 otherwise. Also, for argument base 0 only, underscore
+// characters are permitted as defined by the Go syntax for
+// integer literals.
+//
+// The errors that ParseUint256 returns have concrete type
+// *strconv.NumError and include err.Num = s. If s is empty
+// or contains invalid digits, err.Err = strconv.ErrSyntax
+// and the returned value is 0; if the value corresponding
+// to s cannot be represented by a Uint256, err.Err =
+// strconv.ErrRange and the returned value is 1<<256-1.
+func ParseUint256(s string, base int) (Uint256, error) {
+	const fn = "ParseUint256"
+	var w [4]uint64
+	err := parseWords(w[:], s, base, fn)
+	return Uint256{w[0], w[1], w[2], w[3]}, err
+}
+
+// SetString sets z to the value of s, interpreted in the
+// given base.
+//
+// SetString accepts the same input as ParseUint256. If
+// SetString returns an error, z is left unchanged.
+func (z *Uint256) SetString(s string, base int) error {
+	return z.setString(s, base, "SetString")
+}
+
+// setString implements SetString. fn is the name used in
+// errors.
+func (z *Uint256) setString(s string, base int, fn string) error {
+	var w [4]uint64
+	if err := parseWords(w[:], s, base, fn); err != nil {
+		return err
+	}
+	*z = Uint256{w[0], w[1], w[2], w[3]}
+	return nil
+}
+
+// Scan is a support routine for fmt.Scanner; it sets z to
+// the value of the scanned number.
+//
+// It accepts the formats 'b' (binary), 'o' (octal), 'd'
+// (decimal), 'x' (lowercase hexadecimal), and 'X'
+// (uppercase hexadecimal). The formats 's' and 'v' accept
+// any base prefix that ParseUint256 accepts with base 0.
+func (z *Uint256) Scan(s fmt.ScanState, ch rune) error {
+	tok, base, err := scanToken(s, ch, "Uint256")
+	if err != nil {
+		return err
+	}
+	return z.setString(tok, base, "Scan")
+}
+
+// scanToken implements fmt.Scanner for an integer type
+// with the provided name. It returns the scanned token and
+// the base to parse it in.
+func scanToken(s fmt.ScanState, ch rune, name string) (string, int, error) {
+	var base int
+	switch ch {
+	case 'b':
+		base = 2
+	case 'o':
+		base = 8
+	case 'd':
+		base = 10
+	case 'x', 'X':
+		base = 16
+	case 's', 'v':
+		base = 0
+	default:
+		return "", 0, errors.New("xbits." + name + ".Scan: invalid verb")
+	}
+	s.SkipSpace()
+	tok, err := s.Token(false, func(r rune) bool {
+		if r == '_' {
+			return base == 0
+		}
+		if r >= 0x80 {
+			return false
+		}
+		if base == 0 {
+			// Accept anything that could be part of a prefixed
+			// literal and let parseWords sort it out.
+			return digitVal(byte(r), 36) < 36
+		}
+		return digitVal(byte(r), base) < base
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	return string(tok), base, nil
+}
+
+// parseWords sets z to the little-endian integer
+// represented by s.
+//
+// See ParseUint256 for the accepted input. fn is the name
+// used in errors.
+func parseWords(z []uint64, s string, base int, fn string) error {
+	for i := range z {
+		z[i] = 0
+	}
+
+	if s == "" {
+		return syntaxError(fn, s)
+	}
+	s0 := s
+
+	base0 := base == 0
+	switch {
+	case base0:
+		base = 10
+		if s[0] == '0' {
+			switch {
+			case len(s) >= 3 && lower(s[1]) == 'b':
+				base = 2
+				s = s[2:]
+			case len(s) >= 3 && lower(s[1]) == 'o':
+				base = 8
+				s = s[2:]
+			case len(s) >= 3 && lower(s[1]) == 'x':
+				base = 16
+				s = s[2:]
+			default:
+				base = 8
+				s = s[1:]
+			}
+		}
+	case base < 2 || base > 62:
+		return &strconv.NumError{
+			Func: fn,
+			Num:  s0,
+			Err:  errors.New("invalid base " + strconv.Itoa(base)),
+		}
+	}
+	if base0 && !underscoreOK(s0) {
+		return syntaxError(fn, s0)
+	}
+
+	overflow := false
+	digits := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '_' && base0 {
+			continue
+		}
+		d := digitVal(c, base)
+		if d >= base {
+			for i := range z {
+				z[i] = 0
+			}
+			return syntaxError(fn, s0)
+		}
+		digits++
+		if overflow {
+			continue
+		}
+		if mulAddVWW(z, z, uint64(base), uint64(d)) != 0 {
+			overflow = true
+		}
+	}
+	// The leading "0" of an octal literal counts as a digit.
+	if digits == 0 && !(base0 && base == 8 && len(s) < len(s0)) {
+		return syntaxError(fn, s0)
+	}
+	if overflow {
+		for i := range z {
+			z[i] = 1<<64 - 1
+		}
+		return &strconv.NumError{Func: fn, Num: s0, Err: strconv.ErrRange}
+	}
+	return nil
+}
+
+func syntaxError(fn, s string) *strconv.NumError {
+	return &strconv.NumError{Func: fn, Num: s, Err: strconv.ErrSyntax}
+}
+
+// digitVal returns the value of the digit c in the
+// provided base, or a value >= base if c is not a valid
+// digit.
+func digitVal(c byte, base int) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'a' <= c && c <= 'z':
+		return int(c-'a') + 10
+	case 'A' <= c && c <= 'Z':
+		if base <= 36 {
+			return int(c-'A') + 10
+		}
+		return int(c-'A') + 36
+	}
+	return 62
+}
+
+// lower(c) is a lower-case letter if and only if
+// c is either that lower-case letter or the equivalent
+// upper-case letter.
+//
+// Instead of writing c == 'x' || c == 'X' one can write
+// lower(c) == 'x'.
+//
+// Note that lower of non-letters can produce other
+// non-letters.
+func lower(c byte) byte {
+	return c | ('x' - 'X')
+}
+
+// underscoreOK reports whether the underscores in s are
+// allowed.
+//
+// Underscore must appear only between digits or between
+// a base prefix and a digit.
+//
+// Implementation borrowed from strconv.
+func underscoreOK(s string) bool {
+	// saw tracks the last character (class) we saw:
+	// ^ for beginning of number,
+	// 0 for a digit or base prefix,
+	// _ for an underscore,
+	// ! for none of the above.
+	saw := '^'
+	i := 0
+
+	// Optional base prefix.
+	hex := false
+	if len(s) >= 2 && s[0] == '0' &&
+		(lower(s[1]) == 'b' || lower(s[1]) == 'o' || lower(s[1]) == 'x') {
+		i = 2
+		saw = '0' // base prefix counts as a digit for "underscore as digit separator"
+		hex = lower(s[1]) == 'x'
+	}
+
+	// Number proper.
+	for ; i < len(s); i++ {
+		// Digits are always okay.
+		if '0' <= s[i] && s[i] <= '9' ||
+			hex && 'a' <= lower(s[i]) && lower(s[i]) <= 'f' {
+			saw = '0'
+			continue
+		}
+		// Underscore must follow digit.
+		if s[i] == '_' {
+			if saw != '0' {
+				return false
+			}
+			saw = '_'
+			continue
+		}
+		// Underscore must also be followed by digit.
+		if saw == '_' {
+			return false
+		}
+		// Saw non-digit, non-underscore.
+		saw = '!'
+	}
+	return saw != '_'
+}
+
+// digitSet contains the digits used by appendWords, the
+// same as the digits used by math/big.
+const digitSet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// appendWords appends the textual representation of the
+// little-endian integer x in the provided base to dst and
+// returns the extended buffer.
+//
+// appendWords does not allocate unless dst does not have
+// enough capacity or len(x) > 8.
+//
+// The base must be in [2, 62].
+func appendWords(dst []byte, x []uint64, base int) []byte {
+	if base < 2 || base > 62 {
+		panic("xbits: invalid base")
+	}
+
+	// Strip leading zeros.
+	n := len(x)
+	for n > 0 && x[n-1] == 0 {
+		n--
+	}
+	if n == 0 {
+		return append(dst, '0')
+	}
+	x = x[:n]
+
+	if base&(base-1) == 0 {
+		return appendPow2(dst, x, uint(bits.TrailingZeros(uint(base))))
+	}
+
+	// The digits are written least significant first, then
+	// reversed in place.
+	start := len(dst)
+	dst = appendDiv(dst, x, uint64(base))
+	for i, j := start, len(dst)-1; i < j; i, j = i+1, j-1 {
+		dst[i], dst[j] = dst[j], dst[i]
+	}
+	return dst
+}
+
+// appendPow2 appends the digits of x in base 1<<shift to
+// dst.
+//
+// x must not have leading zero words.
+func appendPow2(dst []byte, x []uint64, shift uint) []byte {
+	nbits := uint(64*(len(x)-1) + bits.Len64(x[len(x)-1]))
+	n := int((nbits + shift - 1) / shift)
+
+	start := len(dst)
+	if cap(dst)-start < n {
+		dst = append(dst, make([]byte, n)...)
+	} else {
+		dst = dst[:start+n]
+	}
+	buf := dst[start:]
+
+	mask := uint64(1)<<shift - 1
+	if 64%shift == 0 {
+		// Digits do not straddle words.
+		k := len(buf)
+		for _, w := range x {
+			for j := uint(0); j < 64 && k > 0; j += shift {
+				k--
+				buf[k] = digitSet[w&mask]
+				w >>= shift
+			}
+		}
+		return dst
+	}
+	for k := range buf {
+		i := uint(len(buf)-1-k) * shift
+		w, s := i/64, i%64
+		d := x[w] >> s
+		if s+shift > 64 && int(w+1) < len(x) {
+			d |= x[w+1] << (64 - s)
+		}
+		buf[k] = digitSet[d&mask]
+	}
+	return dst
+}
+
+// appendDiv appends the digits of x in the provided base
+// to dst, least significant first.
+//
+// x must not have leading zero words.
+func appendDiv(dst []byte, x []uint64, base uint64) []byte {
+	// bb is the largest power of base that fits in a word
+	// and ndigits is the number of digits in bb-1.
+	bb, ndigits := base, 1
+	for {
+		hi, lo := bits.Mul64(bb, base)
+		if hi != 0 {
+			break
+		}
+		bb = lo
+		ndigits++
+	}
+	rec := reciprocal(bb)
+
+	var buf [8]uint64
+	q := buf[:]
+	if len(x) > len(buf) {
+		q = make([]uint64, len(x))
+	}
+	q = q[:copy(q, x)]
+
+	for len(q) > 0 {
+		// q, r = q/bb, q%bb
+		var r uint64
+		for i := len(q) - 1; i >= 0; i-- {
+			q[i], r = divWW(r, q[i], bb, rec)
+		}
+		for len(q) > 0 && q[len(q)-1] == 0 {
+			q = q[:len(q)-1]
+		}
+
+		// Convert r to ndigits digits, or as many digits as
+		// needed if r is the most significant chunk.
+		last := len(q) == 0
+		if base == 10 {
+			// Special case to allow the compiler to use
+			// multiplication instead of division.
+			for j := 0; j < ndigits && (!last || r != 0); j++ {
+				dst = append(dst, digitSet[r%10])
+				r /= 10
+			}
+		} else {
+			for j := 0; j < ndigits && (!last || r != 0); j++ {
+				dst = append(dst, digitSet[r%base])
+				r /= base
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,332 @@
+package xbits
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestParseUint256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		base := 2 + rand.Intn(61)
+		s := x.Text(base)
+
+		got, err := ParseUint256(s, base)
+		if err != nil {
+			t.Fatalf("#%d: ParseUint256(%q, %d): unexpected error: %v", i, s, base, err)
+		}
+		if got != x {
+			t.Fatalf("#%d: ParseUint256(%q, %d): expected %d, got %d", i, s, base, x, got)
+		}
+
+		var z Uint256
+		if err := z.SetString(s, base); err != nil {
+			t.Fatalf("#%d: SetString(%q, %d): unexpected error: %v", i, s, base, err)
+		}
+		if z != x {
+			t.Fatalf("#%d: SetString(%q, %d): expected %d, got %d", i, s, base, x, z)
+		}
+	}
+}
+
+func TestParseUint256Prefix(t *testing.T) {
+	for i, tc := range []struct {
+		s    string
+		base int
+		err  error
+	}{
+		{"0", 0, nil},
+		{"00", 0, nil},
+		{"0x0", 0, nil},
+		{"0xdeadbeef", 0, nil},
+		{"0XDEADBEEF", 0, nil},
+		{"0o777", 0, nil},
+		{"0O777", 0, nil},
+		{"0777", 0, nil},
+		{"0b1011", 0, nil},
+		{"0B1011", 0, nil},
+		{"1_000_000", 0, nil},
+		{"0x_dead_beef", 0, nil},
+		{"0_777", 0, nil},
+		{"0b_1_0", 0, nil},
+		{"deadbeef", 16, nil},
+		{"DEADBEEF", 16, nil},
+		{"zZ", 62, nil},
+		{"115792089237316195423570985008687907853269984665640564039457584007913129639935", 10, nil},
+		{"0x" + max256.Text(16), 0, nil},
+
+		{"", 0, strconv.ErrSyntax},
+		{"", 10, strconv.ErrSyntax},
+		{"0x", 0, strconv.ErrSyntax},
+		{"0b", 0, strconv.ErrSyntax},
+		{"0o", 0, strconv.ErrSyntax},
+		{"0x_", 0, strconv.ErrSyntax},
+		{"-1", 0, strconv.ErrSyntax},
+		{"+1", 0, strconv.ErrSyntax},
+		{" 1", 10, strconv.ErrSyntax},
+		{"1 ", 10, strconv.ErrSyntax},
+		{"0x10", 16, strconv.ErrSyntax},
+		{"0b2", 0, strconv.ErrSyntax},
+		{"08", 0, strconv.ErrSyntax},
+		{"0o8", 0, strconv.ErrSyntax},
+		{"12a", 10, strconv.ErrSyntax},
+		{"1_000", 10, strconv.ErrSyntax},
+		{"_1", 0, strconv.ErrSyntax},
+		{"1_", 0, strconv.ErrSyntax},
+		{"1__0", 0, strconv.ErrSyntax},
+		{"0x1__0", 0, strconv.ErrSyntax},
+		{"Z", 36, nil},
+		{"Z", 35, strconv.ErrSyntax},
+		{"é", 10, strconv.ErrSyntax},
+
+		{"115792089237316195423570985008687907853269984665640564039457584007913129639936", 10, strconv.ErrRange},
+		{"0x1" + max256.Text(16), 0, strconv.ErrRange},
+		{"1" + U256(0).Text(2) + max256.Text(2), 2, strconv.ErrRange},
+		// Syntax errors take precedence over range errors.
+		{max256.Text(10) + "0x", 10, strconv.ErrSyntax},
+	} {
+		z, err := ParseUint256(tc.s, tc.base)
+		if !errors.Is(err, tc.err) {
+			t.Fatalf("#%d: ParseUint256(%q, %d): expected %v, got %v",
+				i, tc.s, tc.base, tc.err, err)
+		}
+		if err != nil {
+			var ne *strconv.NumError
+			if !errors.As(err, &ne) {
+				t.Fatalf("#%d: expected *strconv.NumError, got %T", i, err)
+			}
+			if ne.Func != "ParseUint256" || ne.Num != tc.s {
+				t.Fatalf("#%d: unexpected error: %v", i, err)
+			}
+			want := Uint256{}
+			if tc.err == strconv.ErrRange {
+				want = max256
+			}
+			if z != want {
+				t.Fatalf("#%d: expected %d, got %d", i, want, z)
+			}
+			continue
+		}
+
+		// Compare against strconv where possible.
+		if u, err := strconv.ParseUint(tc.s, tc.base, 64); err == nil {
+			if z != U256(u) {
+				t.Fatalf("#%d: ParseUint256(%q, %d): expected %d, got %d",
+					i, tc.s, tc.base, u, z)
+			}
+		}
+		// And against math/big for everything else.
+		b, ok := new(big.Int).SetString(tc.s, tc.base)
+		if !ok {
+			t.Fatalf("#%d: big.Int.SetString(%q, %d) failed", i, tc.s, tc.base)
+		}
+		if cmpInt(b, z) != 0 {
+			t.Fatalf("#%d: ParseUint256(%q, %d): expected %s, got %d",
+				i, tc.s, tc.base, b, z)
+		}
+	}
+}
+
+func TestParseUint256Base(t *testing.T) {
+	for _, base := range []int{-1, 1, 63, 100} {
+		_, err := ParseUint256("1", base)
+		var ne *strconv.NumError
+		if !errors.As(err, &ne) {
+			t.Fatalf("%d: expected *strconv.NumError, got %v", base, err)
+		}
+		if want := "invalid base " + strconv.Itoa(base); ne.Err.Error() != want {
+			t.Fatalf("%d: expected %q, got %q", base, want, ne.Err)
+		}
+	}
+}
+
+func TestSetStringUnchanged(t *testing.T) {
+	z := U256(42)
+	if err := z.SetString("1x", 10); err == nil {
+		t.Fatal("expected an error")
+	}
+	if err := z.SetString(max256.Text(10)+"0", 10); err == nil {
+		t.Fatal("expected an error")
+	}
+	if z != U256(42) {
+		t.Fatalf("SetString modified z: %d", z)
+	}
+}
+
+func TestScan256(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		x, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, format := range []string{
+			"%b", "%o", "%d", "%x", "%X", "%v", "%s", "%#x", "%#o", "%#b",
+		} {
+			s := fmt.Sprintf(format, x)
+			verb := format
+			if format[1] == '#' {
+				verb = "%v"
+			}
+			var z Uint256
+			if _, err := fmt.Sscanf(s, verb, &z); err != nil {
+				t.Fatalf("#%d: Sscanf(%q, %q): unexpected error: %v", i, s, verb, err)
+			}
+			if z != x {
+				t.Fatalf("#%d: Sscanf(%q, %q): expected %d, got %d", i, s, verb, x, z)
+			}
+		}
+	}
+
+	var x, y Uint256
+	n, err := fmt.Sscan("  123 0x_ff", &x, &y)
+	if err != nil || n != 2 {
+		t.Fatalf("Sscan: expected (2, nil), got (%d, %v)", n, err)
+	}
+	if x != U256(123) || y != U256(255) {
+		t.Fatalf("Sscan: expected (123, 255), got (%d, %d)", x, y)
+	}
+
+	// Scanning stops at the first character that isn't a
+	// digit in the base.
+	var s string
+	n, err = fmt.Sscanf("1011201", "%b%s", &x, &s)
+	if err != nil || n != 2 {
+		t.Fatalf("Sscanf: expected (2, nil), got (%d, %v)", n, err)
+	}
+	if x != U256(11) || s != "201" {
+		t.Fatalf("Sscanf: expected (11, %q), got (%d, %q)", "201", x, s)
+	}
+
+	if _, err := fmt.Sscan(max256.Text(10)+"0", &x); !errors.Is(err, strconv.ErrRange) {
+		t.Fatalf("Sscan: expected %v, got %v", strconv.ErrRange, err)
+	}
+	if _, err := fmt.Sscanf("1", "%c", &x); err == nil {
+		t.Fatal("Sscanf: expected an error for an invalid verb")
+	}
+}
+
+func TestAppendText256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch i {
+		case 0:
+			x = max256
+		case 1:
+			x = Uint256{}
+		}
+		var bx big.Int
+		setInt(&bx, x)
+		for base := 2; base <= 62; base++ {
+			want := bx.Text(base)
+			if got := x.Text(base); got != want {
+				t.Fatalf("#%d: Text(%d): expected %q, got %q", i, base, want, got)
+			}
+			if got := string(x.AppendText([]byte("x="), base)); got != "x="+want {
+				t.Fatalf("#%d: AppendText(%d): expected %q, got %q", i, base, "x="+want, got)
+			}
+		}
+	}
+}
+
+func TestAppendTextWidths(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		x128 := rand128(t)
+		x512 := rand512(t)
+		for base := 2; base <= 62; base++ {
+			var b big.Int
+			setInt128(&b, x128)
+			if got, want := x128.Text(base), b.Text(base); got != want {
+				t.Fatalf("#%d: Uint128.Text(%d): expected %q, got %q", i, base, want, got)
+			}
+			setInt512(&b, x512)
+			if got, want := x512.Text(base), b.Text(base); got != want {
+				t.Fatalf("#%d: Uint512.Text(%d): expected %q, got %q", i, base, want, got)
+			}
+		}
+	}
+}
+
+func TestAppendTextAllocs(t *testing.T) {
+	x := max256
+	buf := make([]byte, 0, 256)
+	for base := 2; base <= 62; base++ {
+		n := testing.AllocsPerRun(100, func() {
+			buf = x.AppendText(buf[:0], base)
+		})
+		if n != 0 {
+			t.Fatalf("AppendText(%d): expected 0 allocations, got %.1f", base, n)
+		}
+	}
+	if n := testing.AllocsPerRun(100, func() {
+		Sink256 = x
+		_ = Sink256.String()
+	}); n > 1 {
+		t.Fatalf("String: expected at most 1 allocation, got %.1f", n)
+	}
+}
+
+func TestAppendTextBase(t *testing.T) {
+	for _, base := range []int{-1, 0, 1, 63} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%d: expected a panic", base)
+				}
+			}()
+			U256(1).AppendText(nil, base)
+		}()
+	}
+}
+
+var sinkBytes []byte
+
+func BenchmarkText256(b *testing.B) {
+	x := max256
+	for _, base := range []int{2, 10, 16, 62} {
+		b.Run(fmt.Sprintf("big/%d", base), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var z big.Int
+				setInt(&z, x)
+				sinkBytes = []byte(z.Text(base))
+			}
+		})
+		b.Run(fmt.Sprintf("AppendText/%d", base), func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, 256)
+			for i := 0; i < b.N; i++ {
+				buf = x.AppendText(buf[:0], base)
+			}
+			sinkBytes = buf
+		})
+	}
+}
+
+func BenchmarkString256(b *testing.B) {
+	b.ReportAllocs()
+	x := max256
+	for i := 0; i < b.N; i++ {
+		sinkBytes = append(sinkBytes[:0], x.String()...)
+	}
+}
+
+func BenchmarkFormat256(b *testing.B) {
+	b.ReportAllocs()
+	x := max256
+	var buf bytes.Buffer
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		fmt.Fprintf(&buf, "%d", x)
+	}
+}
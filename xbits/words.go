@@ -0,0 +1,280 @@
+package xbits
+
+import (
+	"math/bits"
+
+	"github.com/elagergren/ctb/xbits/ct"
+)
+
+// This file contains arithmetic on little-endian word
+// slices shared by the types generated by gen_uint.go.
+
+// maxWords is the number of words in the widest type that
+// gen_uint.go can generate.
+const maxWords = 1024 / 64
+
+// selectWords sets z = x if v == 1 and z = y if v == 0.
+//
+// The result is undefined if v is anything other than 1
+// or 0.
+//
+// This function's execution time does not depend on its
+// inputs.
+func selectWords(v uint64, z, x, y []uint64) {
+	for i := range z {
+		z[i] = ct.Select64(v, x[i], y[i])
+	}
+}
+
+// condSwapWords swaps x and y if v == 1 and leaves them
+// unchanged if v == 0.
+//
+// The result is undefined if v is anything other than 1
+// or 0.
+//
+// This function's execution time does not depend on its
+// inputs.
+func condSwapWords(v uint64, x, y []uint64) {
+	mask := -v
+	for i := range x {
+		t := (x[i] ^ y[i]) & mask
+		x[i] ^= t
+		y[i] ^= t
+	}
+}
+
+// addMaskWords sets z = z + (x&mask) and returns the
+// carry.
+//
+// This function's execution time does not depend on its
+// inputs.
+func addMaskWords(z, x []uint64, mask uint64) (c uint64) {
+	for i := range z {
+		z[i], c = bits.Add64(z[i], x[i]&mask, c)
+	}
+	return c
+}
+
+// mulWords sets z = x*y.
+//
+// mulWords has the following conditions:
+//
+//	len(z) == len(x) + len(y)
+//
+// This function's execution time does not depend on its
+// inputs.
+func mulWords(z, x, y []uint64) {
+	for i := range z {
+		z[i] = 0
+	}
+	for i, v := range y {
+		z[i+len(x)] = addMulVVW(z[i:i+len(x)], x, v)
+	}
+}
+
+// sqrWords sets z = x*x.
+//
+// sqrWords has the following conditions:
+//
+//	len(z) == 2*len(x)
+//
+// This function's execution time does not depend on its
+// inputs.
+func sqrWords(z, x []uint64) {
+	n := len(x)
+	for i := range z {
+		z[i] = 0
+	}
+
+	// Each product x_i*x_j with i != j occurs twice, so sum
+	// the products with i < j, double them, then add the
+	// squares x_i^2.
+	for i := 0; i < n-1; i++ {
+		z[n+i] = addMulVVW(z[2*i+1:n+i], x[i+1:], x[i])
+	}
+	shl(z, z, 1)
+
+	var c uint64
+	for i, v := range x {
+		hi, lo := bits.Mul64(v, v)
+		z[2*i], c = bits.Add64(z[2*i], lo, c)
+		z[2*i+1], c = bits.Add64(z[2*i+1], hi, c)
+	}
+}
+
+// quoRemCTWords sets r = u mod m for the little-endian
+// integer u. If q is not nil, quoRemCTWords also sets
+// q = u / m.
+//
+// quoRemCTWords has the following conditions:
+//
+//	len(r) == len(m)
+//	len(q) == len(u) if q != nil
+//
+// Like quoRemCT, quoRemCTWords uses bit-serial long
+// division, so its running time is proportional to len(u).
+//
+// This function's execution time does not depend on the
+// values of its inputs.
+func quoRemCTWords(q, r, u, m []uint64) {
+	for i := range r {
+		r[i] = 0
+	}
+	for i := len(u) - 1; i >= 0; i-- {
+		var qi uint64
+		for j := 63; j >= 0; j-- {
+			// r = 2r + bit, keeping the carry.
+			c := shl(r, r, 1)
+			r[0] |= (u[i] >> uint(j)) & 1
+
+			// If r >= m, set the quotient bit. Otherwise,
+			// undo the subtraction.
+			b := subVV(r, r, m)
+			v := c | (b ^ 1)
+			addMaskWords(r, m, v-1)
+			qi |= v << uint(j)
+		}
+		if q != nil {
+			q[i] = qi
+		}
+	}
+}
+
+// subModWords sets z = x - y (mod m) for x, y in [0, m).
+//
+// This function's execution time does not depend on its
+// inputs.
+func subModWords(z, x, y, m []uint64) {
+	b := subVV(z, x, y)
+	addMaskWords(z, m, -b)
+}
+
+// halveModWords sets x = x/2 (mod m) for x in [0, m) and
+// an odd m.
+//
+// This function's execution time does not depend on its
+// inputs.
+func halveModWords(x, m []uint64) {
+	// If x is odd, x + m is even and (x+m)/2 = x/2 (mod m).
+	c := addMaskWords(x, m, -(x[0] & 1))
+	shr(x, x, 1)
+	x[len(x)-1] |= c << 63
+}
+
+// modInverseCTWords sets v to the multiplicative inverse
+// of a in the ring ℤ/nℤ and returns 1, or sets v to an
+// unspecified value and returns 0 if a and n are not
+// relatively prime.
+//
+// modInverseCTWords has the following conditions:
+//
+//	n is odd
+//	len(a) == len(n) == len(v)
+//
+// modInverseCTWords overwrites a.
+//
+// This function's execution time does not depend on its
+// inputs.
+func modInverseCTWords(v, a, n []uint64) uint64 {
+	// This is the same algorithm as Uint256.ModInverseCT.
+	var bbuf, ubuf, tbuf [maxWords]uint64
+	b := bbuf[:len(n)]
+	u := ubuf[:len(n)]
+	t := tbuf[:len(n)]
+
+	copy(b, n)
+	for i := range v {
+		v[i] = 0
+	}
+	// u = 1 mod n.
+	u[0] = 1 ^ isOneWords(n)
+
+	for i := 0; i < 2*64*len(n); i++ {
+		odd := a[0] & 1
+
+		// If a is odd and a < b, swap (a, b) and (u, v).
+		lt := subVV(t, a, b)
+		swap := odd & lt
+		condSwapWords(swap, a, b)
+		condSwapWords(swap, u, v)
+
+		// If a is odd, set a = a - b and u = u - v (mod n).
+		// Afterward a is always even.
+		subVV(t, a, b)
+		selectWords(odd, a, t, a)
+		subModWords(t, u, v, n)
+		selectWords(odd, u, t, u)
+
+		// Set a = a/2 and u = u/2 (mod n).
+		shr(a, a, 1)
+		halveModWords(u, n)
+	}
+	return isOneWords(b)
+}
+
+// isOneWords returns 1 if x == 1 and 0 otherwise.
+//
+// This function's execution time does not depend on its
+// inputs.
+func isOneWords(x []uint64) uint64 {
+	r := x[0] ^ 1
+	for _, v := range x[1:] {
+		r |= v
+	}
+	return ct.Equal64(r, 0)
+}
+
+// montMulWords sets z = x*y*R^-1 (mod m), where
+// R = 2^(64*len(m)), for x, y in [0, m).
+//
+// k must be -m^-1 mod 2^64.
+//
+// montMulWords has the following conditions:
+//
+//	m is odd
+//	len(z) == len(x) == len(y) == len(m)
+//
+// z may alias x or y.
+//
+// This function's execution time does not depend on its
+// inputs.
+func montMulWords(z, x, y, m []uint64, k uint64) {
+	n := len(m)
+	var tbuf [maxWords + 2]uint64
+	t := tbuf[:n+2]
+
+	// This is the CIOS method from Ç. K. Koç, T. Acar, and
+	// B. S. Kaliski, "Analyzing and Comparing Montgomery
+	// Multiplication Algorithms" (1996).
+	for i := 0; i < n; i++ {
+		// t += x*y_i
+		c := addMulVVW(t[:n], x, y[i])
+		t[n], c = bits.Add64(t[n], c, 0)
+		t[n+1] = c
+
+		// t += m*(t_0*k), which makes t_0 zero, then
+		// t /= 2^64.
+		c = addMulVVW(t[:n], m, t[0]*k)
+		t[n], c = bits.Add64(t[n], c, 0)
+		t[n+1] += c
+		copy(t, t[1:])
+		t[n+1] = 0
+	}
+
+	// t < 2m, so subtract m at most once.
+	b := subVV(z, t[:n], m)
+	selectWords(t[n]|(b^1), z, z, t[:n])
+}
+
+// montInverse returns -m^-1 mod 2^64 for an odd m.
+//
+// This function's execution time does not depend on its
+// inputs.
+func montInverse(m uint64) uint64 {
+	// Newton's method, as in Montgomery.init.
+	inv := m
+	for i := 0; i < 5; i++ {
+		inv *= 2 - m*inv
+	}
+	return -inv
+}
@@ -1,11 +1,14 @@
 // Package xbits augments math/bits with larger integers.
 package xbits
 
+//go:generate go run gen_uint.go 128 384 448 512
+
 import (
 	"crypto/subtle"
 	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"math/bits"
 
@@ -17,6 +20,11 @@ type Uint256 struct {
 	u0, u1, u2, u3 uint64
 }
 
+// max256 is 1<<256-1.
+var max256 = Uint256{
+	math.MaxUint64, math.MaxUint64, math.MaxUint64, math.MaxUint64,
+}
+
 var (
 	_ fmt.Stringer  = Uint256{}
 	_ fmt.Formatter = Uint256{}
@@ -27,6 +35,16 @@ func U256(x uint64) Uint256 {
 	return Uint256{x, 0, 0, 0}
 }
 
+// u256 creates a Uint256 from little-endian words.
+func u256(w [4]uint64) Uint256 {
+	return Uint256{w[0], w[1], w[2], w[3]}
+}
+
+// words returns x as little-endian words.
+func (x Uint256) words() [4]uint64 {
+	return [4]uint64{x.u0, x.u1, x.u2, x.u3}
+}
+
 // Add returns x + y.
 //
 // This function's execution time does not depend on its inputs.
@@ -41,6 +59,24 @@ func (x Uint256) Add(y Uint256) Uint256 {
 	return z
 }
 
+// AddChecked returns x + y and reports whether
+// the sum did not overflow.
+//
+// This function's execution time does not depend on its inputs.
+func (x Uint256) AddChecked(y Uint256) (Uint256, bool) {
+	z, c := Add256(x, y, 0)
+	return z, c == 0
+}
+
+// AddSat returns x + y, saturating at 1<<256-1 instead
+// of overflowing.
+//
+// This function's execution time does not depend on its inputs.
+func (x Uint256) AddSat(y Uint256) Uint256 {
+	z, c := Add256(x, y, 0)
+	return Select256(c, max256, z)
+}
+
 // And returns x & y.
 //
 // This function's execution time does not depend on its inputs.
@@ -53,40 +89,49 @@ func (x Uint256) And(y Uint256) Uint256 {
 	return z
 }
 
+// AppendText appends the textual representation of x in
+// the provided base to dst and returns the extended
+// buffer.
+//
+// AppendText does not allocate if dst has enough
+// capacity. The base must be in [2, 62].
+func (x Uint256) AppendText(dst []byte, base int) []byte {
+	return appendWords(dst, []uint64{x.u0, x.u1, x.u2, x.u3}, base)
+}
+
 // Bit returns the value of bit at index i.
 //
 // That is, Bit returns (x>>i)&1.
 // The index must be >= 0.
+//
+// This function's execution time does not depend on its
+// inputs, except for whether i < 0.
 func (x Uint256) Bit(i int) uint {
 	if i < 0 {
 		panic("negative bit index")
 	}
-	if i >= 256 {
-		return 0
-	}
-	n := make([]uint64, 4)
-	n[0] = x.u0
-	n[1] = x.u1
-	n[2] = x.u2
-	n[3] = x.u3
-	return uint(n[i/64] >> (i % 64) & 1)
+	// Select the word without indexing by i. If i >= 256,
+	// no word is selected.
+	k := uint64(i) / 64
+	w := ct.Select64(ct.Equal64(k, 0), x.u0, 0)
+	w |= ct.Select64(ct.Equal64(k, 1), x.u1, 0)
+	w |= ct.Select64(ct.Equal64(k, 2), x.u2, 0)
+	w |= ct.Select64(ct.Equal64(k, 3), x.u3, 0)
+	return uint(w >> (uint(i) % 64) & 1)
 }
 
 // BitLen returns the absolute value of x in bits.
 //
 // In other words, the number of bits needed to
 // represent x.
+//
+// This function's execution time does not depend on its inputs.
 func (x Uint256) BitLen() int {
-	switch {
-	case x.u3 != 0:
-		return 192 + bits.Len64(x.u3)
-	case x.u2 != 0:
-		return 128 + bits.Len64(x.u2)
-	case x.u1 != 0:
-		return 64 + bits.Len64(x.u1)
-	default:
-		return bits.Len64(x.u0)
-	}
+	n := uint64(bits.Len64(x.u0))
+	n = ct.Select64(nonzero64(x.u1), 64+uint64(bits.Len64(x.u1)), n)
+	n = ct.Select64(nonzero64(x.u2), 128+uint64(bits.Len64(x.u2)), n)
+	n = ct.Select64(nonzero64(x.u3), 192+uint64(bits.Len64(x.u3)), n)
+	return int(n)
 }
 
 // Cmp compares u and x and returns
@@ -95,6 +140,7 @@ func (x Uint256) BitLen() int {
 //     0 if x == y
 //    -1 if x < y
 //
+// See CmpCT for a constant-time version.
 func (x Uint256) Cmp(y Uint256) int {
 	var z Uint256
 	var b uint64
@@ -103,53 +149,180 @@ func (x Uint256) Cmp(y Uint256) int {
 	z.u2, b = bits.Sub64(x.u2, y.u2, b)
 	z.u3, b = bits.Sub64(x.u3, y.u3, b)
 
-	r := z.u0 ^ z.u1 ^ z.u2 ^ z.u3
+	r := z.u0 | z.u1 | z.u2 | z.u3
 	// If r == 0 then x == y
 	// If r != 0 then x != y
-	// If b == 0 then x > y
-	// If b == 1 then x <= y
-	if b == 0 {
-		return +1
+	// If b == 0 then x >= y
+	// If b == 1 then x < y
+	if b == 1 {
+		return -1
 	}
 	if r == 0 {
 		return +0
 	}
-	return -1
+	return +1
+}
+
+// CmpCT compares x and y and returns
+//
+//    lt = 1 if x < y and 0 otherwise
+//    eq = 1 if x == y and 0 otherwise
+//
+// so x > y if lt|eq == 0.
+//
+// This function's execution time does not depend on its inputs.
+func (x Uint256) CmpCT(y Uint256) (lt, eq uint64) {
+	z, b := Sub256(x, y, 0)
+	return b, ct.Equal64(z.u0|z.u1|z.u2|z.u3, 0)
+}
+
+// Eq returns 1 if x == y and 0 otherwise.
+//
+// This function's execution time does not depend on its inputs.
+func (x Uint256) Eq(y Uint256) uint64 {
+	return eq256(x, y)
 }
 
 // Exp returns x**y mod m.
+//
+// See ExpCT for a constant-time version.
 func (x Uint256) Exp(y, m Uint256) Uint256 {
-	x1 := x
-	x2 := x.MulMod(x, m)
-	for i := 256 - 2; i >= 0; i-- {
+	x1 := U256(1).Rem(m)
+	x2 := x
+	for i := 256 - 1; i >= 0; i-- {
 		if y.Bit(i) == 0 {
 			// x2 = x1*x2 mod m
 			x2 = x1.MulMod(x2, m)
 			// x1 = x1^2 mod m
-			x1 = x1.MulMod(x1, m)
+			x1 = x1.sqrMod(m)
 		} else {
 			// x1 = x1*x2 mod m
 			x1 = x1.MulMod(x2, m)
 			// x2 = x2^2 mod m
-			x2 = x2.MulMod(x2, m)
+			x2 = x2.sqrMod(m)
+		}
+	}
+	return x1
+}
+
+// ExpCT returns x**y mod m.
+//
+// ExpCT panics if m == 0.
+//
+// This function's execution time does not depend on x or y.
+func (x Uint256) ExpCT(y, m Uint256) Uint256 {
+	if m.BitLen() == 0 {
+		panic("division by zero")
+	}
+	if m.u0&1 == 1 {
+		z := NewMontgomery(m)
+		return z.FromMont(ladder(z.ToMont(x), z.One(), y, z.Mul, z.Sqr))
+	}
+	z := NewBarrett(m)
+	return ladder(z.Reduce(x), z.one, y, z.mul, z.sqr)
+}
+
+// ladder returns x**y using a Montgomery ladder with
+// the provided multiplication and squaring.
+//
+// one is the multiplicative identity.
+//
+// This function's execution time does not depend on its
+// inputs, provided mul's and sqr's do not.
+func ladder(x, one, y Uint256, mul func(x, y Uint256) Uint256, sqr func(x Uint256) Uint256) Uint256 {
+	exp := [4]uint64{y.u0, y.u1, y.u2, y.u3}
+	x1 := one
+	x2 := x
+	for i := len(exp) - 1; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			bit := (exp[i] >> uint(j)) & 1
+			// If bit == 0:
+			//    x2 = x1*x2
+			//    x1 = x1^2
+			// If bit == 1:
+			//    x1 = x1*x2
+			//    x2 = x2^2
+			x1, x2 = CondSwap256(bit, x1, x2)
+			x2 = mul(x1, x2)
+			x1 = sqr(x1)
+			x1, x2 = CondSwap256(bit, x1, x2)
 		}
 	}
 	return x1
 }
 
+// mulModCT returns x*y mod m.
+//
+// This function's execution time does not depend on its
+// inputs.
+func mulModCT(x, y, m Uint256) Uint256 {
+	var z [8]uint64
+	mul512(z[:], x, y)
+	return quoRemCT(nil, z[:], m)
+}
+
+// modCT returns x mod m.
+//
+// This function's execution time does not depend on its
+// inputs.
+func modCT(x, m Uint256) Uint256 {
+	return quoRemCT(nil, []uint64{x.u0, x.u1, x.u2, x.u3}, m)
+}
+
+// quoRemCT returns u mod m for the little-endian
+// integer u. If q is not nil, quoRemCT also sets q to
+// u / m, in which case len(q) must be at least len(u).
+//
+// quoRemCT uses bit-serial long division, so its running
+// time is proportional to len(u).
+//
+// This function's execution time does not depend on the
+// values of its inputs.
+func quoRemCT(q, u []uint64, m Uint256) Uint256 {
+	var r Uint256
+	for i := len(u) - 1; i >= 0; i-- {
+		var qi uint64
+		for j := 63; j >= 0; j-- {
+			// r = 2r + bit, keeping the carry.
+			c := r.u3 >> 63
+			r.u3 = r.u3<<1 | r.u2>>63
+			r.u2 = r.u2<<1 | r.u1>>63
+			r.u1 = r.u1<<1 | r.u0>>63
+			r.u0 = r.u0<<1 | (u[i]>>uint(j))&1
+
+			// If r >= m, set r = r - m and set the
+			// quotient bit.
+			d, b := Sub256(r, m, 0)
+			v := c | (b ^ 1)
+			r = Select256(v, d, r)
+			qi |= v << uint(j)
+		}
+		if q != nil {
+			q[i] = qi
+		}
+	}
+	return r
+}
+
 // FillBytes sets buf to x, storing it as a zero-extended
 // big-endian byte slice, and returns buf.
 //
-// If x does not fit into buf, FillBytes will panic.
+// If buf is smaller than 32 bytes, FillBytes will panic.
 func (x Uint256) FillBytes(buf []byte) []byte {
-	binary.BigEndian.PutUint64(buf[24:], x.u0)
-	binary.BigEndian.PutUint64(buf[16:24], x.u1)
-	binary.BigEndian.PutUint64(buf[8:16], x.u2)
-	binary.BigEndian.PutUint64(buf[:8], x.u3)
-	return buf
+	return fillBytes(buf, []uint64{x.u0, x.u1, x.u2, x.u3}, "FillBytes")
 }
 
 func (x Uint256) Format(s fmt.State, ch rune) {
+	format(s, ch, "Uint256", false, []uint64{x.u0, x.u1, x.u2, x.u3})
+}
+
+// format implements fmt.Formatter for an integer
+// type with the provided name.
+//
+// x is the absolute value of the integer as
+// little-endian words. The integer is negative if
+// neg is true.
+func format(s fmt.State, ch rune, name string, neg bool, x []uint64) {
 	// Implementation borrowed from math/big.
 
 	var base int
@@ -163,12 +336,18 @@ func (x Uint256) Format(s fmt.State, ch rune) {
 	case 'x', 'X':
 		base = 16
 	default:
-		fmt.Fprintf(s, "%%!%c(xbits.Uint256=%s)", ch, x.String())
+		minus := ""
+		if neg {
+			minus = "-"
+		}
+		fmt.Fprintf(s, "%%!%c(xbits.%s=%s%s)", ch, name, minus, appendWords(nil, x, 10))
 		return
 	}
 
 	sign := ""
 	switch {
+	case neg:
+		sign = "-"
 	case s.Flag('+'):
 		sign = "+"
 	case s.Flag(' '):
@@ -192,7 +371,8 @@ func (x Uint256) Format(s fmt.State, ch rune) {
 		prefix = "0o"
 	}
 
-	digits := []byte(x.Text(base))
+	var buf [512]byte
+	digits := appendWords(buf[:0], x, base)
 	if ch == 'X' {
 		for i, d := range digits {
 			if 'a' <= d && d <= 'z' {
@@ -238,13 +418,22 @@ func (x Uint256) Format(s fmt.State, ch rune) {
 // write count copies of text to s
 func writeMultiple(s fmt.State, text string, count int) {
 	if len(text) > 0 {
-		b := []byte(text)
 		for ; count > 0; count-- {
-			s.Write(b)
+			io.WriteString(s, text)
 		}
 	}
 }
 
+// Hi returns the high 128 bits of x.
+func (x Uint256) Hi() Uint128 {
+	return Uint128{x.u2, x.u3}
+}
+
+// Lo returns the low 128 bits of x.
+func (x Uint256) Lo() Uint128 {
+	return Uint128{x.u0, x.u1}
+}
+
 // Lsh returns x<<n.
 //
 // This function's execution time does not depend on its inputs.
@@ -256,7 +445,7 @@ func (x Uint256) Lsh(n uint) Uint256 {
 	// Otherwise, set i = 4.
 	i := subtle.ConstantTimeSelect(int(ct.LessOrEq(n, 255)), int(n/64), 4)
 
-	res := make([]uint64, 8)
+	var res [8]uint64
 	res[i+3] = x.u3<<s | x.u2>>ŝ
 	res[i+2] = x.u2<<s | x.u1>>ŝ
 	res[i+1] = x.u1<<s | x.u0>>ŝ
@@ -283,149 +472,408 @@ func shl(z, x []uint64, n uint) uint64 {
 	return c
 }
 
+// Lt returns 1 if x < y and 0 otherwise.
+//
+// This function's execution time does not depend on its inputs.
+func (x Uint256) Lt(y Uint256) uint64 {
+	_, b := Sub256(x, y, 0)
+	return b
+}
+
+// IsZero returns 1 if x == 0 and 0 otherwise.
+//
+// This function's execution time does not depend on its inputs.
+func (x Uint256) IsZero() uint64 {
+	return ct.Equal64(x.u0|x.u1|x.u2|x.u3, 0)
+}
+
+// ModCT returns x mod m.
+//
+// ModCT panics if m == 0.
+//
+// This function's execution time does not depend on x or
+// m, except for whether m == 0.
+func (x Uint256) ModCT(m Uint256) Uint256 {
+	if eq256(m, Uint256{}) == 1 {
+		panic("division by zero")
+	}
+	return modCT(x, m)
+}
+
 // ModInverse returns the multiplicative inverse
 // of x in the ring ℤ/nℤ.
 //
 // If x and n are not relatively prime, x has no
 // multiplicative inverse in the ring ℤ/nℤ and
 // ModInverse will panic.
+//
+// If n is odd, ModInverse uses ModInverseCT. Otherwise,
+// it uses the extended Euclidean algorithm, which is not
+// constant time.
 func (x Uint256) ModInverse(n Uint256) Uint256 {
-	panic("TODO")
+	z, ok := modInverse(x, n)
+	if !ok {
+		panic("xbits: no multiplicative inverse")
+	}
+	return z
+}
+
+// modInverse returns the multiplicative inverse of x in
+// the ring ℤ/nℤ and reports whether it exists.
+//
+// modInverse panics if n == 0.
+func modInverse(x, n Uint256) (Uint256, bool) {
+	if n.u0&1 == 0 {
+		if n.BitLen() == 0 {
+			panic("division by zero")
+		}
+		g, a, _ := x.Rem(n).ExtendedGCD(n)
+		if g != U256(1) {
+			return Uint256{}, false
+		}
+		// |a| <= n/2, so a mod n is either a or n+a.
+		if a.Sign() < 0 {
+			return n.Sub(Uint256(a.Neg())), true
+		}
+		return Uint256(a), true
+	}
+	z, ok := x.ModInverseCT(n)
+	return z, ok == 1
+}
+
+// ModInverseCT returns the multiplicative inverse
+// of x in the ring ℤ/nℤ and 1, or an unspecified
+// value and 0 if x and n are not relatively prime.
+//
+// ModInverseCT panics if n is even.
+//
+// This function's execution time does not depend on its
+// inputs.
+func (x Uint256) ModInverseCT(n Uint256) (Uint256, uint64) {
+	if n.u0&1 == 0 {
+		panic("xbits: ModInverseCT: modulus must be odd")
+	}
+
+	// This is the constant-time binary extended GCD from
+	// J. Bos, "Constant Time Modular Inversion" (2014).
+	//
+	// We maintain the invariants
+	//
+	//    a = u*x (mod n)
+	//    b = v*x (mod n)
+	//
+	// and b is always odd. Each iteration removes at least
+	// one bit from len(a) + len(b), so after 2*256 iterations
+	// a = 0 and b = gcd(x, n).
+	a, b := x, n
+	// u = 1 mod n.
+	u := U256(1 ^ eq256(n, U256(1)))
+	var v Uint256
+	for i := 0; i < 2*256; i++ {
+		odd := a.u0 & 1
+
+		// If a is odd and a < b, swap (a, b) and (u, v).
+		_, lt := Sub256(a, b, 0)
+		swap := odd & lt
+		a, b = CondSwap256(swap, a, b)
+		u, v = CondSwap256(swap, u, v)
+
+		// If a is odd, set a = a - b and u = u - v (mod n).
+		// Afterward a is always even.
+		a = Select256(odd, a.Sub(b), a)
+		u = Select256(odd, subMod256(u, v, n), u)
+
+		// Set a = a/2 and u = u/2 (mod n).
+		a = a.Rsh(1)
+		u = halveMod256(u, n)
+	}
+	return v, eq256(b, U256(1))
+}
+
+// subMod256 returns x - y (mod m) for x, y in [0, m).
+//
+// This function's execution time does not depend on its
+// inputs.
+func subMod256(x, y, m Uint256) Uint256 {
+	z, b := Sub256(x, y, 0)
+	return Select256(b, z.Add(m), z)
+}
+
+// halveMod256 returns x/2 (mod m) for x in [0, m)
+// and an odd m.
+//
+// This function's execution time does not depend on its
+// inputs.
+func halveMod256(x, m Uint256) Uint256 {
+	// If x is odd, x + m is even and (x+m)/2 = x/2 (mod m).
+	odd := x.u0 & 1
+	s, c := Add256(x, m, 0)
+	s = Select256(odd, s, x)
+	c &= odd
+	z := s.Rsh(1)
+	z.u3 |= c << 63
+	return z
 }
 
 // Mul returns x * y.
 //
 // This function's execution time does not depend on its inputs.
 func (x Uint256) Mul(y Uint256) Uint256 {
-	z := make([]uint64, 8)
-	mul512(z, x, y)
-	return Uint256{z[0], z[1], z[2], z[3]}
+	return mul256(x, y)
+}
+
+// MulChecked returns x * y and reports whether
+// the product did not overflow.
+//
+// This function's execution time does not depend on its inputs.
+func (x Uint256) MulChecked(y Uint256) (Uint256, bool) {
+	hi, lo := Mul256(x, y)
+	return lo, eq256(hi, Uint256{}) == 1
+}
+
+// MulFull returns the full 512-bit product of x * y.
+//
+// This function's execution time does not depend on its inputs.
+func (x Uint256) MulFull(y Uint256) Uint512 {
+	var z [8]uint64
+	mul512(z[:], x, y)
+	return u512(z)
+}
+
+// Sqr returns x^2.
+//
+// Sqr is faster than x.Mul(x).
+//
+// This function's execution time does not depend on its inputs.
+func (x Uint256) Sqr() Uint256 {
+	return sqr256(x)
+}
+
+// SqrFull returns the full 512-bit square of x.
+//
+// SqrFull is faster than x.MulFull(x).
+//
+// This function's execution time does not depend on its inputs.
+func (x Uint256) SqrFull() Uint512 {
+	var z [8]uint64
+	sqr512(z[:], x)
+	return u512(z)
+}
+
+// MulSat returns x * y, saturating at 1<<256-1 instead
+// of overflowing.
+//
+// This function's execution time does not depend on its inputs.
+func (x Uint256) MulSat(y Uint256) Uint256 {
+	hi, lo := Mul256(x, y)
+	return Select256(eq256(hi, Uint256{}), lo, max256)
 }
 
 // MulMod returns x*y mod m.
+//
+// See MulModCT for a constant-time version.
 func (x Uint256) MulMod(y, m Uint256) Uint256 {
-	z := make([]uint64, 8)
-	mul512(z, x, y)
+	// div512 requires an extra zero word.
+	var z [9]uint64
+	mul512(z[:8], x, y)
+	return rem512(&z, m)
+}
 
+// sqrMod returns x^2 mod m.
+func (x Uint256) sqrMod(m Uint256) Uint256 {
+	// div512 requires an extra zero word.
+	var z [9]uint64
+	sqr512(z[:8], x)
+	return rem512(&z, m)
+}
+
+// rem512 returns z[0:8] mod m.
+//
+// z[8] must be zero.
+func rem512(z *[9]uint64, m Uint256) Uint256 {
 	if m.BitLen() <= 64 {
-		return U256(mod64(z, m.u0))
+		return U256(mod64(z[:], m.u0))
 	}
 
-	v := make([]uint64, 4)
-	v[0] = m.u0
-	v[1] = m.u1
-	v[2] = m.u2
-	v[3] = m.u3
-
-	q := make([]uint64, 8)
-	r := div512(q, z, v)
+	v := m.words()
+	var q [9]uint64
+	r := div512(q[:], z[:], v[:])
 	return Uint256{r[0], r[1], r[2], r[3]}
 }
 
-// mul512 returns the 512-bit product of x*y.
+// MulModCT returns x*y mod m.
 //
-// mul512 has the following conditions:
+// MulModCT panics if m == 0.
+//
+// This function's execution time does not depend on x, y,
+// or m, except for whether m == 0.
+func (x Uint256) MulModCT(y, m Uint256) Uint256 {
+	if eq256(m, Uint256{}) == 1 {
+		panic("division by zero")
+	}
+	return mulModCT(x, y, m)
+}
+
+// mul512Generic sets z to the 512-bit product of x*y.
+//
+// mul512Generic has the following conditions:
 //
 //    len(z) == 8
 //
+// mul512Generic uses Comba's method: each column of partial
+// products x_i*y_j with i+j = k is summed into a three-word
+// accumulator before z_k is stored, so each output word is
+// written exactly once.
+//
 // This function's execution time does not depend on its inputs.
-func mul512(z []uint64, x, y Uint256) {
-	var (
-		c      uint64
-		z1, z0 uint64
-	)
+func mul512Generic(z []uint64, x, y Uint256) {
+	_ = z[7] // bounds check hint
 
-	// y_0 * x
-	//
-	// Store in z[0:4]
+	var c0, c1, c2 uint64
 
-	c, z[0] = mul128(x.u0, y.u0, z[0])
+	c0, c1, c2 = mulAddCol(x.u0, y.u0, c0, c1, c2)
+	z[0], c0, c1, c2 = c0, c1, c2, 0
 
-	z1, z0 = mul128(x.u1, y.u0, z[1])
-	lo, cc := bits.Add64(z0, c, 0)
-	c, z[1] = cc, lo
-	c += z1
+	c0, c1, c2 = mulAddCol(x.u0, y.u1, c0, c1, c2)
+	c0, c1, c2 = mulAddCol(x.u1, y.u0, c0, c1, c2)
+	z[1], c0, c1, c2 = c0, c1, c2, 0
 
-	z1, z0 = mul128(x.u2, y.u0, z[2])
-	lo, cc = bits.Add64(z0, c, 0)
-	c, z[2] = cc, lo
-	c += z1
+	c0, c1, c2 = mulAddCol(x.u0, y.u2, c0, c1, c2)
+	c0, c1, c2 = mulAddCol(x.u1, y.u1, c0, c1, c2)
+	c0, c1, c2 = mulAddCol(x.u2, y.u0, c0, c1, c2)
+	z[2], c0, c1, c2 = c0, c1, c2, 0
 
-	z1, z0 = mul128(x.u3, y.u0, z[3])
-	lo, cc = bits.Add64(z0, c, 0)
-	c, z[3] = cc, lo
-	c += z1
+	c0, c1, c2 = mulAddCol(x.u0, y.u3, c0, c1, c2)
+	c0, c1, c2 = mulAddCol(x.u1, y.u2, c0, c1, c2)
+	c0, c1, c2 = mulAddCol(x.u2, y.u1, c0, c1, c2)
+	c0, c1, c2 = mulAddCol(x.u3, y.u0, c0, c1, c2)
+	z[3], c0, c1, c2 = c0, c1, c2, 0
 
-	z[4] = c
+	c0, c1, c2 = mulAddCol(x.u1, y.u3, c0, c1, c2)
+	c0, c1, c2 = mulAddCol(x.u2, y.u2, c0, c1, c2)
+	c0, c1, c2 = mulAddCol(x.u3, y.u1, c0, c1, c2)
+	z[4], c0, c1, c2 = c0, c1, c2, 0
 
-	// y_1 * x
-	//
-	// Store in z[1:5]
+	c0, c1, c2 = mulAddCol(x.u2, y.u3, c0, c1, c2)
+	c0, c1, c2 = mulAddCol(x.u3, y.u2, c0, c1, c2)
+	z[5], c0, c1 = c0, c1, c2
 
-	c, z[1] = mul128(x.u0, y.u1, z[1])
+	c0, c1, _ = mulAddCol(x.u3, y.u3, c0, c1, 0)
+	z[6], z[7] = c0, c1
+}
 
-	z1, z0 = mul128(x.u1, y.u1, z[2])
-	lo, cc = bits.Add64(z0, c, 0)
-	c, z[2] = cc, lo
-	c += z1
+// mul256 returns the low 256 bits of x*y.
+//
+// Only the partial products that contribute to the low four
+// columns are computed, and only the low halves of those in
+// the fourth column.
+//
+// This function's execution time does not depend on its inputs.
+func mul256(x, y Uint256) Uint256 {
+	var (
+		z          Uint256
+		c0, c1, c2 uint64
+	)
 
-	z1, z0 = mul128(x.u2, y.u1, z[3])
-	lo, cc = bits.Add64(z0, c, 0)
-	c, z[3] = cc, lo
-	c += z1
+	c0, c1, c2 = mulAddCol(x.u0, y.u0, c0, c1, c2)
+	z.u0, c0, c1, c2 = c0, c1, c2, 0
 
-	z1, z0 = mul128(x.u3, y.u1, z[4])
-	lo, cc = bits.Add64(z0, c, 0)
-	c, z[4] = cc, lo
-	c += z1
+	c0, c1, c2 = mulAddCol(x.u0, y.u1, c0, c1, c2)
+	c0, c1, c2 = mulAddCol(x.u1, y.u0, c0, c1, c2)
+	z.u1, c0, c1 = c0, c1, c2
 
-	z[5] = c
+	c0, c1, _ = mulAddCol(x.u0, y.u2, c0, c1, 0)
+	c0, c1, _ = mulAddCol(x.u1, y.u1, c0, c1, 0)
+	c0, c1, _ = mulAddCol(x.u2, y.u0, c0, c1, 0)
+	z.u2 = c0
 
-	// y_2 * x
-	//
-	// Store in z[2:6]
+	z.u3 = c1 + x.u0*y.u3 + x.u1*y.u2 + x.u2*y.u1 + x.u3*y.u0
+	return z
+}
 
-	c, z[2] = mul128(x.u0, y.u2, z[2])
+// sqr512Generic sets z to the 512-bit square of x.
+//
+// sqr512Generic has the following conditions:
+//
+//    len(z) == 8
+//
+// Since x_i*x_j = x_j*x_i, each off-diagonal product is
+// computed once and doubled, so squaring requires 10 word
+// multiplications instead of 16.
+//
+// This function's execution time does not depend on its inputs.
+func sqr512Generic(z []uint64, x Uint256) {
+	_ = z[7] // bounds check hint
 
-	z1, z0 = mul128(x.u1, y.u2, z[3])
-	lo, cc = bits.Add64(z0, c, 0)
-	c, z[3] = cc, lo
-	c += z1
+	var c0, c1, c2 uint64
 
-	z1, z0 = mul128(x.u2, y.u2, z[4])
-	lo, cc = bits.Add64(z0, c, 0)
-	c, z[4] = cc, lo
-	c += z1
+	c0, c1, c2 = mulAddCol(x.u0, x.u0, c0, c1, c2)
+	z[0], c0, c1, c2 = c0, c1, c2, 0
 
-	z1, z0 = mul128(x.u3, y.u2, z[5])
-	lo, cc = bits.Add64(z0, c, 0)
-	c, z[5] = cc, lo
-	c += z1
+	c0, c1, c2 = mulAddCol2(x.u0, x.u1, c0, c1, c2)
+	z[1], c0, c1, c2 = c0, c1, c2, 0
 
-	z[6] = c
+	c0, c1, c2 = mulAddCol2(x.u0, x.u2, c0, c1, c2)
+	c0, c1, c2 = mulAddCol(x.u1, x.u1, c0, c1, c2)
+	z[2], c0, c1, c2 = c0, c1, c2, 0
 
-	// y_3 * x
-	//
-	// Store in z[3:7]
+	c0, c1, c2 = mulAddCol2(x.u0, x.u3, c0, c1, c2)
+	c0, c1, c2 = mulAddCol2(x.u1, x.u2, c0, c1, c2)
+	z[3], c0, c1, c2 = c0, c1, c2, 0
+
+	c0, c1, c2 = mulAddCol2(x.u1, x.u3, c0, c1, c2)
+	c0, c1, c2 = mulAddCol(x.u2, x.u2, c0, c1, c2)
+	z[4], c0, c1, c2 = c0, c1, c2, 0
+
+	c0, c1, c2 = mulAddCol2(x.u2, x.u3, c0, c1, c2)
+	z[5], c0, c1 = c0, c1, c2
+
+	c0, c1, _ = mulAddCol(x.u3, x.u3, c0, c1, 0)
+	z[6], z[7] = c0, c1
+}
 
-	c, z[3] = mul128(x.u0, y.u3, z[3])
+// sqr256 returns the low 256 bits of x^2.
+//
+// This function's execution time does not depend on its inputs.
+func sqr256(x Uint256) Uint256 {
+	var (
+		z          Uint256
+		c0, c1, c2 uint64
+	)
 
-	z1, z0 = mul128(x.u1, y.u3, z[4])
-	lo, cc = bits.Add64(z0, c, 0)
-	c, z[4] = cc, lo
-	c += z1
+	c0, c1, c2 = mulAddCol(x.u0, x.u0, c0, c1, c2)
+	z.u0, c0, c1, c2 = c0, c1, c2, 0
 
-	z1, z0 = mul128(x.u2, y.u3, z[5])
-	lo, cc = bits.Add64(z0, c, 0)
-	c, z[5] = cc, lo
-	c += z1
+	c0, c1, c2 = mulAddCol2(x.u0, x.u1, c0, c1, c2)
+	z.u1, c0, c1 = c0, c1, c2
 
-	z1, z0 = mul128(x.u3, y.u3, z[6])
-	lo, cc = bits.Add64(z0, c, 0)
-	c, z[6] = cc, lo
-	c += z1
+	c0, c1, _ = mulAddCol2(x.u0, x.u2, c0, c1, 0)
+	c0, c1, _ = mulAddCol(x.u1, x.u1, c0, c1, 0)
+	z.u2 = c0
 
-	z[7] = c
+	z.u3 = c1 + 2*(x.u0*x.u3+x.u1*x.u2)
+	return z
+}
+
+// mulAddCol returns (c2, c1, c0) + x*y.
+func mulAddCol(x, y, c0, c1, c2 uint64) (uint64, uint64, uint64) {
+	hi, lo := bits.Mul64(x, y)
+	var c uint64
+	c0, c = bits.Add64(c0, lo, 0)
+	c1, c = bits.Add64(c1, hi, c)
+	return c0, c1, c2 + c
+}
+
+// mulAddCol2 returns (c2, c1, c0) + 2*x*y.
+func mulAddCol2(x, y, c0, c1, c2 uint64) (uint64, uint64, uint64) {
+	hi, lo := bits.Mul64(x, y)
+	c2 += hi >> 63
+	hi = hi<<1 | lo>>63
+	lo <<= 1
+	var c uint64
+	c0, c = bits.Add64(c0, lo, 0)
+	c1, c = bits.Add64(c1, hi, c)
+	return c0, c1, c2 + c
 }
 
 func mul128(x, y, c uint64) (z1, z0 uint64) {
@@ -479,6 +927,8 @@ func (x Uint256) Quo(y Uint256) Uint256 {
 //
 // QuoRem implements truncated division and
 // modulus, like Go.
+//
+// See QuoRemCT for a constant-time version.
 func (x Uint256) QuoRem(y Uint256) (Uint256, Uint256) {
 	// QuoRem is largely borrowed from math/big.
 
@@ -497,26 +947,31 @@ func (x Uint256) QuoRem(y Uint256) (Uint256, Uint256) {
 		return q, U256(r)
 	}
 
-	u := make([]uint64, 5)
-	u[0] = x.u0
-	u[1] = x.u1
-	u[2] = x.u2
-	u[3] = x.u3
-
-	v := make([]uint64, 4)
-	v[0] = y.u0
-	v[1] = y.u1
-	v[2] = y.u2
-	v[3] = y.u3
-
-	q := make([]uint64, 5)
-	r := div512(q, u, v)
+	u := [5]uint64{x.u0, x.u1, x.u2, x.u3}
+	v := y.words()
+	var q [5]uint64
+	r := div512(q[:], u[:], v[:])
 
 	quo := Uint256{q[0], q[1], q[2], q[3]}
 	rem := Uint256{r[0], r[1], r[2], r[3]}
 	return quo, rem
 }
 
+// QuoRemCT returns x / y and x % y.
+//
+// QuoRemCT panics if y == 0.
+//
+// This function's execution time does not depend on x or
+// y, except for whether y == 0.
+func (x Uint256) QuoRemCT(y Uint256) (Uint256, Uint256) {
+	if eq256(y, Uint256{}) == 1 {
+		panic("division by zero")
+	}
+	var q [4]uint64
+	r := quoRemCT(q[:], []uint64{x.u0, x.u1, x.u2, x.u3}, y)
+	return Uint256{q[0], q[1], q[2], q[3]}, r
+}
+
 // mod64 return u%v.
 func mod64(uIn []uint64, v uint64) (r uint64) {
 	rec := reciprocal(v)
@@ -534,6 +989,7 @@ func mod64(uIn []uint64, v uint64) (r uint64) {
 //    len(u) >= len(v) + 1
 //    u[len(u)-1] must be zero (reserved for r)
 //    len(q) >= len(u)
+//    q must be zero
 //    u must not alias z
 //
 // div512 reuses u as storage for r.
@@ -541,7 +997,7 @@ func mod64(uIn []uint64, v uint64) (r uint64) {
 func div512(q, uIn, vIn []uint64) (r []uint64) {
 	_ = vIn[2-1]
 	_ = uIn[len(vIn)+1-1]
-	_ = q[len(uIn)-len(vIn)+1-1]
+	_ = q[len(uIn)-1]
 
 	// Normalize v.
 	n := len(vIn)
@@ -552,12 +1008,15 @@ func div512(q, uIn, vIn []uint64) (r []uint64) {
 
 	// Normalize u.
 	uIn[len(uIn)-1] = 0
-	m := len(uIn) - 1
-	for m > 0 && uIn[m-1] == 0 {
-		m--
+	ul := len(uIn) - 1
+	for ul > 0 && uIn[ul-1] == 0 {
+		ul--
+	}
+	if ul < n {
+		// u < v, so q = 0 and r = u.
+		return uIn
 	}
-	uIn = uIn[:m]
-	m -= n
+	m := ul - n
 
 	// D1.
 	shift := uint(bits.LeadingZeros64(vIn[n-1]))
@@ -565,17 +1024,40 @@ func div512(q, uIn, vIn []uint64) (r []uint64) {
 	v := vIn
 	shl(v, vIn, shift)
 
-	u := uIn[:len(uIn)+1]
-	u[m] = shl(u[:len(uIn)], uIn, shift)
+	u := uIn[:ul+1]
+	u[ul] = shl(u[:ul], uIn[:ul], shift)
 
-	q = q[:m+1]
+	divNorm(q[:m+1], u, v, reciprocal(v[n-1]))
+	shr(u, u, shift)
+	return uIn
+}
 
-	qhatv := make([]uint64, 8)
-	qhatvLen := n + 1
+// divNorm sets q = u/v and u = u%v for a normalized v.
+//
+// divNorm has the following conditions:
+//
+//    len(v) >= 2
+//    v[len(v)-1] has its high bit set
+//    u[len(u)-1] < v[len(v)-1]
+//    len(q) == len(u) - len(v)
+//    rec == reciprocal(v[len(v)-1])
+//
+// divNorm implements steps D2 through D7 of Knuth's
+// Algorithm D; the caller is responsible for
+// normalization.
+func divNorm(q, u, v []uint64, rec uint64) {
+	n := len(v)
+	m := len(q) - 1
+
+	var qhatvBuf [9]uint64
+	qhatv := qhatvBuf[:]
+	if n+1 > len(qhatv) {
+		qhatv = make([]uint64, n+1)
+	}
+	qhatv = qhatv[:n+1]
 
 	// D2.
 	vn1 := v[n-1]
-	rec := reciprocal(vn1)
 	for j := m; j >= 0; j-- {
 		// D3.
 		const mask = 1<<64 - 1
@@ -609,7 +1091,7 @@ func div512(q, uIn, vIn []uint64) (r []uint64) {
 		// Compute the remainder u - (q̂*v) << (_W*j).
 		// The subtraction may overflow if q̂ estimate was off by one.
 		qhatv[n] = mulAddVWW(qhatv[0:n], v, qhat, 0)
-		qhl := qhatvLen
+		qhl := len(qhatv)
 		if j+qhl > len(u) && qhatv[n] == 0 {
 			qhl--
 		}
@@ -624,14 +1106,8 @@ func div512(q, uIn, vIn []uint64) (r []uint64) {
 			qhat--
 		}
 
-		if j == m && m == len(q) && qhat == 0 {
-			continue
-		}
 		q[j] = qhat
 	}
-	shr(u, u, shift)
-	r = u
-	return r
 }
 
 // Rem returns x % y.
@@ -664,10 +1140,10 @@ func (x Uint256) RotateRight(k int) Uint256 {
 // reversed order.
 func (x Uint256) Reverse() Uint256 {
 	var z Uint256
-	z.u0 = bits.Reverse64(x.u0)
-	z.u1 = bits.Reverse64(x.u1)
-	z.u2 = bits.Reverse64(x.u2)
-	z.u3 = bits.Reverse64(x.u3)
+	z.u0 = bits.Reverse64(x.u3)
+	z.u1 = bits.Reverse64(x.u2)
+	z.u2 = bits.Reverse64(x.u1)
+	z.u3 = bits.Reverse64(x.u0)
 	return z
 }
 
@@ -677,10 +1153,10 @@ func (x Uint256) Reverse() Uint256 {
 // This function's execution time does not depend on its inputs.
 func (x Uint256) ReverseBytes() Uint256 {
 	var z Uint256
-	z.u0 = bits.ReverseBytes64(x.u0)
-	z.u1 = bits.ReverseBytes64(x.u1)
-	z.u2 = bits.ReverseBytes64(x.u2)
-	z.u3 = bits.ReverseBytes64(x.u3)
+	z.u0 = bits.ReverseBytes64(x.u3)
+	z.u1 = bits.ReverseBytes64(x.u2)
+	z.u2 = bits.ReverseBytes64(x.u1)
+	z.u3 = bits.ReverseBytes64(x.u0)
 	return z
 }
 
@@ -691,7 +1167,7 @@ func (x Uint256) Rsh(n uint) Uint256 {
 	s := n % 64
 	ŝ := 64 - s
 
-	res := make([]uint64, 8)
+	var res [8]uint64
 	res[0] = x.u0>>s | x.u1<<ŝ
 	res[1] = x.u1>>s | x.u2<<ŝ
 	res[2] = x.u2>>s | x.u3<<ŝ
@@ -715,10 +1191,10 @@ func shr(z, x []uint64, n uint) uint64 {
 	ŝ := 64 - s
 
 	c := x[0] << ŝ
-	z[0] = x[0]>>s | x[1]<<ŝ
-	z[1] = x[1]>>s | x[2]<<ŝ
-	z[2] = x[2]>>s | x[3]<<ŝ
-	z[3] = x[3] >> s
+	for i := 0; i < len(z)-1; i++ {
+		z[i] = x[i]>>s | x[i+1]<<ŝ
+	}
+	z[len(z)-1] = x[len(z)-1] >> s
 	return c
 }
 
@@ -768,7 +1244,7 @@ func be64(b []byte) uint64 {
 	case 4:
 		return uint64(binary.BigEndian.Uint32(b))
 	case 3:
-		_ = b[3] // bounds check hint to compiler; see golang.org/issue/14808
+		_ = b[2] // bounds check hint to compiler; see golang.org/issue/14808
 		return uint64(b[2]) | uint64(b[1])<<8 | uint64(b[0])<<16
 	case 2:
 		return uint64(binary.BigEndian.Uint16(b))
@@ -794,6 +1270,24 @@ func (x Uint256) Sub(y Uint256) Uint256 {
 	return z
 }
 
+// SubChecked returns x - y and reports whether
+// the difference did not underflow.
+//
+// This function's execution time does not depend on its inputs.
+func (x Uint256) SubChecked(y Uint256) (Uint256, bool) {
+	z, b := Sub256(x, y, 0)
+	return z, b == 0
+}
+
+// SubSat returns x - y, saturating at 0 instead of
+// underflowing.
+//
+// This function's execution time does not depend on its inputs.
+func (x Uint256) SubSat(y Uint256) Uint256 {
+	z, b := Sub256(x, y, 0)
+	return Select256(b, Uint256{}, z)
+}
+
 func (x Uint256) String() string {
 	return x.Text(10)
 }
@@ -803,26 +1297,22 @@ func (x Uint256) String() string {
 //
 // The base must be in [2, 62].
 func (x Uint256) Text(base int) string {
-	var z big.Int
-	setInt(&z, x)
-	return z.Text(base)
+	var buf [256]byte
+	return string(x.AppendText(buf[:0], base))
 }
 
 // TrailingZeros returns the number of trailing
 // zero bits in x.
 //
 // The result is 256 if x == 0.
+//
+// This function's execution time does not depend on its inputs.
 func (x Uint256) TrailingZeros() int {
-	switch {
-	case x.u0 != 0:
-		return bits.TrailingZeros64(x.u0)
-	case x.u1 != 0:
-		return 64 + bits.TrailingZeros64(x.u1)
-	case x.u2 != 0:
-		return 128 + bits.TrailingZeros64(x.u2)
-	default:
-		return 192 + bits.TrailingZeros64(x.u3)
-	}
+	n := 192 + uint64(bits.TrailingZeros64(x.u3))
+	n = ct.Select64(nonzero64(x.u2), 128+uint64(bits.TrailingZeros64(x.u2)), n)
+	n = ct.Select64(nonzero64(x.u1), 64+uint64(bits.TrailingZeros64(x.u1)), n)
+	n = ct.Select64(nonzero64(x.u0), uint64(bits.TrailingZeros64(x.u0)), n)
+	return int(n)
 }
 
 // Uint64 returns the uint64 representation of x.
@@ -845,8 +1335,107 @@ func (x Uint256) Xor(y Uint256) Uint256 {
 	return z
 }
 
+// Add256 returns the sum with carry of x, y and carry:
+// sum = x + y + carry.
+//
+// The carry input must be 0 or 1; otherwise the behavior
+// is undefined. The carryOut output is guaranteed to be
+// 0 or 1.
+//
+// This function's execution time does not depend on its inputs.
+func Add256(x, y Uint256, carry uint64) (sum Uint256, carryOut uint64) {
+	c := carry
+	sum.u0, c = bits.Add64(x.u0, y.u0, c)
+	sum.u1, c = bits.Add64(x.u1, y.u1, c)
+	sum.u2, c = bits.Add64(x.u2, y.u2, c)
+	sum.u3, c = bits.Add64(x.u3, y.u3, c)
+	return sum, c
+}
+
+// Sub256 returns the difference of x, y and borrow:
+// diff = x - y - borrow.
+//
+// The borrow input must be 0 or 1; otherwise the behavior
+// is undefined. The borrowOut output is guaranteed to be
+// 0 or 1.
+//
+// This function's execution time does not depend on its inputs.
+func Sub256(x, y Uint256, borrow uint64) (diff Uint256, borrowOut uint64) {
+	b := borrow
+	diff.u0, b = bits.Sub64(x.u0, y.u0, b)
+	diff.u1, b = bits.Sub64(x.u1, y.u1, b)
+	diff.u2, b = bits.Sub64(x.u2, y.u2, b)
+	diff.u3, b = bits.Sub64(x.u3, y.u3, b)
+	return diff, b
+}
+
+// Mul256 returns the 512-bit product of x and y:
+// (hi, lo) = x * y with the product bits' upper half
+// returned in hi and the lower half returned in lo.
+//
+// This function's execution time does not depend on its inputs.
+func Mul256(x, y Uint256) (hi, lo Uint256) {
+	var z [8]uint64
+	mul512(z[:], x, y)
+	hi = Uint256{z[4], z[5], z[6], z[7]}
+	lo = Uint256{z[0], z[1], z[2], z[3]}
+	return hi, lo
+}
+
+// nonzero64 returns 1 if x != 0 and 0 otherwise.
+//
+// This function's execution time does not depend on its
+// inputs.
+func nonzero64(x uint64) uint64 {
+	return (x | -x) >> 63
+}
+
+// Select256 returns x if v == 1 and y if v == 0.
+//
+// The result is undefined if v is anything
+// other than 1 or 0.
+//
+// This function's execution time does not depend on its
+// inputs.
+func Select256(v uint64, x, y Uint256) Uint256 {
+	var z Uint256
+	z.u0 = ct.Select64(v, x.u0, y.u0)
+	z.u1 = ct.Select64(v, x.u1, y.u1)
+	z.u2 = ct.Select64(v, x.u2, y.u2)
+	z.u3 = ct.Select64(v, x.u3, y.u3)
+	return z
+}
+
+// CondSwap256 returns (y, x) if v == 1 and (x, y) if v == 0.
+//
+// The result is undefined if v is anything
+// other than 1 or 0.
+//
+// This function's execution time does not depend on its
+// inputs.
+func CondSwap256(v uint64, x, y Uint256) (Uint256, Uint256) {
+	mask := -v
+	t0 := (x.u0 ^ y.u0) & mask
+	t1 := (x.u1 ^ y.u1) & mask
+	t2 := (x.u2 ^ y.u2) & mask
+	t3 := (x.u3 ^ y.u3) & mask
+	x.u0 ^= t0
+	x.u1 ^= t1
+	x.u2 ^= t2
+	x.u3 ^= t3
+	y.u0 ^= t0
+	y.u1 ^= t1
+	y.u2 ^= t2
+	y.u3 ^= t3
+	return x, y
+}
+
 // Rand256 returns a Uint256 in [0, max).
 //
+// Rand256 uses rejection sampling, so the number of bytes
+// it reads from rand depends on max. See RandModReduced for
+// a version that does not.
+//
 // Rand256 panics if max = 0.
 func Rand256(rand io.Reader, max Uint256) (Uint256, error) {
 	// Implementation borrowed from crypto/rand.
@@ -868,7 +1457,8 @@ func Rand256(rand io.Reader, max Uint256) (Uint256, error) {
 		b = 8
 	}
 
-	bytes := make([]byte, k)
+	var buf [32]byte
+	bytes := buf[:k]
 	for {
 		_, err := io.ReadFull(rand, bytes)
 		if err != nil {
@@ -880,32 +1470,80 @@ func Rand256(rand io.Reader, max Uint256) (Uint256, error) {
 		bytes[0] &= uint8(int(1<<b) - 1)
 
 		n.SetBytes(bytes)
-		if n.Cmp(max) < 0 {
+		if n.Lt(max) == 1 {
 			return n, nil
 		}
 	}
 }
 
+// eq256 returns 1 if x == y and 0 otherwise.
+//
+// This function's execution time does not depend on its
+// inputs.
+func eq256(x, y Uint256) uint64 {
+	r := (x.u0 ^ y.u0) | (x.u1 ^ y.u1) | (x.u2 ^ y.u2) | (x.u3 ^ y.u3)
+	return ct.Equal64(r, 0)
+}
+
 func setInt(z *big.Int, x Uint256) {
+	setWords(z, []uint64{x.u0, x.u1, x.u2, x.u3})
+}
+
+// setWords sets z to the little-endian integer x.
+func setWords(z *big.Int, x []uint64) {
 	const _W = bits.UintSize
 	if _W == 64 {
-		z.SetBits([]big.Word{
-			big.Word(x.u0),
-			big.Word(x.u1),
-			big.Word(x.u2),
-			big.Word(x.u3),
-		})
+		w := make([]big.Word, len(x))
+		for i, v := range x {
+			w[i] = big.Word(v)
+		}
+		z.SetBits(w)
 	} else {
 		// _W == 32
-		z.SetBits([]big.Word{
-			big.Word(x.u0),
-			big.Word(x.u0 >> 32),
-			big.Word(x.u1),
-			big.Word(x.u1 >> 32),
-			big.Word(x.u2),
-			big.Word(x.u2 >> 32),
-			big.Word(x.u3),
-			big.Word(x.u3 >> 32),
-		})
+		w := make([]big.Word, 2*len(x))
+		for i, v := range x {
+			w[2*i] = big.Word(v)
+			w[2*i+1] = big.Word(v >> 32)
+		}
+		z.SetBits(w)
+	}
+}
+
+// setBytes sets z to the big-endian integer buf.
+//
+// setBytes panics if len(buf) > 8*len(z).
+func setBytes(z []uint64, buf []byte, fn string) {
+	if len(buf) > 8*len(z) {
+		panic(fn + ": integer too large")
+	}
+	for i := range z {
+		z[i] = 0
+	}
+	for i := 0; len(buf) > 0; i++ {
+		n := len(buf) - 8
+		if n < 0 {
+			n = 0
+		}
+		z[i] = be64(buf[n:])
+		buf = buf[:n]
+	}
+}
+
+// fillBytes sets buf to the little-endian integer x,
+// storing it as a zero-extended big-endian byte slice.
+//
+// fillBytes panics if len(buf) < 8*len(x).
+func fillBytes(buf []byte, x []uint64, fn string) []byte {
+	if len(buf) < 8*len(x) {
+		panic(fn + ": buffer too small")
+	}
+	n := len(buf) - 8*len(x)
+	for i := range buf[:n] {
+		buf[i] = 0
 	}
+	for i := len(x) - 1; i >= 0; i-- {
+		binary.BigEndian.PutUint64(buf[n:], x[i])
+		n += 8
+	}
+	return buf
 }
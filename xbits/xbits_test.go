@@ -1,7 +1,6 @@
 package xbits
 
 import (
-	"fmt"
 	"io"
 	"math"
 	"math/big"
@@ -11,7 +10,6 @@ import (
 
 var (
 	rng    = readFunc(rand.Read)
-	max256 = Uint256{math.MaxUint64, math.MaxUint64, math.MaxUint64, math.MaxUint64}
 	max128 = Uint256{math.MaxUint64, math.MaxUint64, 0, 0}
 )
 
@@ -32,17 +30,31 @@ func cmpInt(x *big.Int, y Uint256) int {
 // big256Mask is 1<<256-1.
 var big256Mask = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
 
-func TestFoo(t *testing.T) {
-	u2 := Uint256{17562291160714782030, 13611842547513532036, 18446744073709551615, 9223372032559808512}
-	N := Uint256{17562291160714782033, 13611842547513532036, 18446744073709551615, 18446744069414584320}
+func TestAdd256(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		x, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		z := x.Add(y)
 
-	sq := u2.Add(u2)
-	fmt.Println(u2)
-	fmt.Println(sq)
-	fmt.Println(N)
+		var bz, bx, by big.Int
+		setInt(&bx, x)
+		setInt(&by, y)
+		bz.Add(&bx, &by)
+		bz.And(&bz, big256Mask)
+
+		if cmpInt(&bz, z) != 0 {
+			t.Fatalf("#%d: expected %s, got %d", i, bz.String(), z)
+		}
+	}
 }
 
-func TestAdd256(t *testing.T) {
+func TestAdd256Carry(t *testing.T) {
 	for i := 0; i < 100_000; i++ {
 		x, err := Rand256(rng, max256)
 		if err != nil {
@@ -52,18 +64,121 @@ func TestAdd256(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		z := x.Add(y)
+		c := uint64(rand.Intn(2))
+		z, cout := Add256(x, y, c)
 
 		var bz, bx, by big.Int
 		setInt(&bx, x)
 		setInt(&by, y)
 		bz.Add(&bx, &by)
+		bz.Add(&bz, new(big.Int).SetUint64(c))
+
+		if want := uint64(bz.Bit(256)); cout != want {
+			t.Fatalf("#%d: expected carry %d, got %d", i, want, cout)
+		}
 		bz.And(&bz, big256Mask)
+		if cmpInt(&bz, z) != 0 {
+			t.Fatalf("#%d: expected %s, got %d", i, bz.String(), z)
+		}
+	}
+	if z, c := Add256(max256, U256(0), 1); z != U256(0) || c != 1 {
+		t.Fatalf("expected (0, 1), got (%d, %d)", z, c)
+	}
+}
+
+func TestSub256Borrow(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		x, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := uint64(rand.Intn(2))
+		z, bout := Sub256(x, y, b)
 
+		var bz, bx, by big.Int
+		setInt(&bx, x)
+		setInt(&by, y)
+		bz.Sub(&bx, &by)
+		bz.Sub(&bz, new(big.Int).SetUint64(b))
+
+		var want uint64
+		if bz.Sign() < 0 {
+			want = 1
+		}
+		if bout != want {
+			t.Fatalf("#%d: expected borrow %d, got %d", i, want, bout)
+		}
+		bz.And(&bz, big256Mask)
 		if cmpInt(&bz, z) != 0 {
 			t.Fatalf("#%d: expected %s, got %d", i, bz.String(), z)
 		}
 	}
+	if z, b := Sub256(U256(0), U256(0), 1); z != max256 || b != 1 {
+		t.Fatalf("expected (%d, 1), got (%d, %d)", max256, z, b)
+	}
+}
+
+func TestMul256Full(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		x, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hi, lo := Mul256(x, y)
+
+		var bz, bx, by, bhi big.Int
+		setInt(&bx, x)
+		setInt(&by, y)
+		bz.Mul(&bx, &by)
+		bhi.Rsh(&bz, 256)
+		bz.And(&bz, big256Mask)
+
+		if cmpInt(&bhi, hi) != 0 {
+			t.Fatalf("#%d: expected hi %s, got %d", i, bhi.String(), hi)
+		}
+		if cmpInt(&bz, lo) != 0 {
+			t.Fatalf("#%d: expected lo %s, got %d", i, bz.String(), lo)
+		}
+	}
+	if hi, lo := Mul256(max256, max256); hi != max256.Sub(U256(1)) || lo != U256(1) {
+		t.Fatalf("expected (%d, 1), got (%d, %d)", max256.Sub(U256(1)), hi, lo)
+	}
+}
+
+func TestSqr256Full(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		x, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch i {
+		case 0:
+			x = Uint256{}
+		case 1:
+			x = max256
+		}
+		z := x.SqrFull()
+
+		var bz, bx big.Int
+		setInt(&bx, x)
+		bz.Mul(&bx, &bx)
+		if cmpInt512(&bz, z) != 0 {
+			t.Fatalf("#%d: %d^2: expected %s, got %d", i, x, bz.String(), z)
+		}
+
+		bz.And(&bz, big256Mask)
+		if got := x.Sqr(); cmpInt(&bz, got) != 0 {
+			t.Fatalf("#%d: %d^2 mod 2^256: expected %s, got %d", i, x, bz.String(), got)
+		}
+	}
 }
 
 func TestSub256(t *testing.T) {
@@ -112,6 +227,62 @@ func TestMul256(t *testing.T) {
 			t.Fatalf("#%d: expected %s, got %d", i, bz.String(), z)
 		}
 	}
+	if z := max256.Mul(max256); z != U256(1) {
+		t.Fatalf("expected 1, got %d", z)
+	}
+}
+
+func TestChecked256(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		checked func(x, y Uint256) (Uint256, bool)
+		sat     func(x, y Uint256) Uint256
+		big     func(z, x, y *big.Int) *big.Int
+	}{
+		{"Add", Uint256.AddChecked, Uint256.AddSat, (*big.Int).Add},
+		{"Sub", Uint256.SubChecked, Uint256.SubSat, (*big.Int).Sub},
+		{"Mul", Uint256.MulChecked, Uint256.MulSat, (*big.Int).Mul},
+	} {
+		for i := 0; i < 100_000; i++ {
+			x, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+			if err != nil {
+				t.Fatal(err)
+			}
+			y, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var bz, bx, by big.Int
+			setInt(&bx, x)
+			setInt(&by, y)
+			tc.big(&bz, &bx, &by)
+			ok := bz.Sign() >= 0 && bz.BitLen() <= 256
+
+			z, zok := tc.checked(x, y)
+			if zok != ok {
+				t.Fatalf("%sChecked #%d: (%d, %d): expected %t, got %t",
+					tc.name, i, x, y, ok, zok)
+			}
+			var bw big.Int
+			bw.And(&bz, big256Mask)
+			if cmpInt(&bw, z) != 0 {
+				t.Fatalf("%sChecked #%d: expected %s, got %d",
+					tc.name, i, bw.String(), z)
+			}
+
+			switch {
+			case bz.Sign() < 0:
+				bz.SetInt64(0)
+			case bz.BitLen() > 256:
+				bz.Set(big256Mask)
+			}
+			if z := tc.sat(x, y); cmpInt(&bz, z) != 0 {
+				t.Fatalf("%sSat #%d: expected %s, got %d",
+					tc.name, i, bz.String(), z)
+			}
+		}
+	}
 }
 
 func TestLsh256(t *testing.T) {
@@ -184,6 +355,131 @@ func TestQuoRem256(t *testing.T) {
 	}
 }
 
+func TestQuoRem256Large(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		x, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if y.BitLen() == 0 {
+			y = U256(1)
+		}
+
+		var bq, br, bx, by big.Int
+		setInt(&bx, x)
+		setInt(&by, y)
+		bq.QuoRem(&bx, &by, &br)
+		q, r := x.QuoRem(y)
+
+		if cmpInt(&bq, q) != 0 {
+			t.Fatalf("#%d: %d/%d: expected %s, got %d", i, x, y, bq.String(), q)
+		}
+		if cmpInt(&br, r) != 0 {
+			t.Fatalf("#%d: %d%%%d: expected %s, got %d", i, x, y, br.String(), r)
+		}
+	}
+}
+
+func TestMulMod256(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		x, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.BitLen() == 0 {
+			m = U256(1)
+		}
+		z := x.MulMod(y, m)
+
+		var bz, bx, by, bm big.Int
+		setInt(&bx, x)
+		setInt(&by, y)
+		setInt(&bm, m)
+		bz.Mul(&bx, &by)
+		bz.Mod(&bz, &bm)
+
+		if cmpInt(&bz, z) != 0 {
+			t.Fatalf("#%d: %d*%d mod %d: expected %s, got %d",
+				i, x, y, m, bz.String(), z)
+		}
+	}
+}
+
+func TestQuoRemCT256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch i {
+		case 0:
+			x, y = max256, U256(1)
+		case 1:
+			x, y = max256, max256
+		case 2:
+			x, y = Uint256{}, max256
+		}
+		if y.BitLen() == 0 {
+			y = U256(1)
+		}
+		if m.BitLen() == 0 {
+			m = U256(1)
+		}
+
+		q, r := x.QuoRem(y)
+		qct, rct := x.QuoRemCT(y)
+		if qct != q || rct != r {
+			t.Fatalf("#%d: QuoRemCT(%d, %d): expected (%d, %d), got (%d, %d)",
+				i, x, y, q, r, qct, rct)
+		}
+		if z := x.ModCT(y); z != r {
+			t.Fatalf("#%d: ModCT(%d, %d): expected %d, got %d", i, x, y, r, z)
+		}
+		if got, want := x.MulModCT(y, m), x.MulMod(y, m); got != want {
+			t.Fatalf("#%d: MulModCT(%d, %d, %d): expected %d, got %d",
+				i, x, y, m, want, got)
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		fn   func()
+	}{
+		{"QuoRemCT", func() { U256(1).QuoRemCT(Uint256{}) }},
+		{"ModCT", func() { U256(1).ModCT(Uint256{}) }},
+		{"MulModCT", func() { U256(1).MulModCT(U256(1), Uint256{}) }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%s: expected a panic", tc.name)
+				}
+			}()
+			tc.fn()
+		}()
+	}
+}
+
 func TestAnd256(t *testing.T) {
 	for i := 0; i < 100_000; i++ {
 		x, err := Rand256(rng, max256)
@@ -256,6 +552,138 @@ func TestOr256(t *testing.T) {
 	}
 }
 
+func TestCmp256(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		x, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rand.Intn(4) == 0 {
+			y = x
+		}
+
+		var bx, by big.Int
+		setInt(&bx, x)
+		setInt(&by, y)
+		if got, want := x.Cmp(y), bx.Cmp(&by); got != want {
+			t.Fatalf("#%d: Cmp(%d, %d): expected %d, got %d", i, x, y, want, got)
+		}
+		c := by.Cmp(&bx)
+		if lt, eq := y.CmpCT(x); (lt == 1) != (c < 0) || (eq == 1) != (c == 0) || lt&eq != 0 {
+			t.Fatalf("#%d: CmpCT(%d, %d): expected %d, got (%d, %d)", i, y, x, c, lt, eq)
+		}
+	}
+}
+
+// TestCmp256Limbs tests Cmp on values that differ in a
+// single limb. Cmp once XORed the limbs of x-y together and
+// tested the borrow backward, so it reported equal values
+// as greater.
+func TestCmp256Limbs(t *testing.T) {
+	for i := 0; i < 4; i++ {
+		w := [4]uint64{1, 2, 3, 4}
+		lo := u256(w)
+		w[i]++
+		hi := u256(w)
+		for _, tc := range []struct {
+			x, y Uint256
+			want int
+		}{
+			{lo, lo, 0},
+			{hi, hi, 0},
+			{lo, hi, -1},
+			{hi, lo, +1},
+			{U256(1).Lsh(64 * uint(i)), Uint256{}, +1},
+			{Uint256{}, U256(1).Lsh(64 * uint(i)), -1},
+			{max256, max256, 0},
+		} {
+			if got := tc.x.Cmp(tc.y); got != tc.want {
+				t.Fatalf("limb %d: Cmp(%#x, %#x): expected %d, got %d",
+					i, tc.x, tc.y, tc.want, got)
+			}
+		}
+	}
+}
+
+func TestPredicates256(t *testing.T) {
+	b2u := func(b bool) uint64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+	for i := 0; i < 100_000; i++ {
+		x, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch rand.Intn(4) {
+		case 0:
+			y = x
+		case 1:
+			x = Uint256{}
+		}
+
+		if got, want := x.Eq(y), b2u(x == y); got != want {
+			t.Fatalf("#%d: Eq(%d, %d): expected %d, got %d", i, x, y, want, got)
+		}
+		if got, want := x.Lt(y), b2u(x.Cmp(y) < 0); got != want {
+			t.Fatalf("#%d: Lt(%d, %d): expected %d, got %d", i, x, y, want, got)
+		}
+		if got, want := x.IsZero(), b2u(x == Uint256{}); got != want {
+			t.Fatalf("#%d: IsZero(%d): expected %d, got %d", i, x, want, got)
+		}
+		if got := Select256(1, x, y); got != x {
+			t.Fatalf("#%d: Select256(1): expected %d, got %d", i, x, got)
+		}
+		if got := Select256(0, x, y); got != y {
+			t.Fatalf("#%d: Select256(0): expected %d, got %d", i, y, got)
+		}
+		if a, b := CondSwap256(1, x, y); a != y || b != x {
+			t.Fatalf("#%d: CondSwap256(1): expected (%d, %d), got (%d, %d)", i, y, x, a, b)
+		}
+		if a, b := CondSwap256(0, x, y); a != x || b != y {
+			t.Fatalf("#%d: CondSwap256(0): expected (%d, %d), got (%d, %d)", i, x, y, a, b)
+		}
+	}
+}
+
+func TestBitLen256(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		x, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rand.Intn(2) == 0 {
+			x = x.Lsh(uint(rand.Intn(256)))
+		}
+		if i == 0 {
+			x = Uint256{}
+		}
+
+		var bx big.Int
+		setInt(&bx, x)
+		if got, want := x.BitLen(), bx.BitLen(); got != want {
+			t.Fatalf("#%d: BitLen(%d): expected %d, got %d", i, x, want, got)
+		}
+		want := 256
+		if x != (Uint256{}) {
+			want = int(bx.TrailingZeroBits())
+		}
+		if got := x.TrailingZeros(); got != want {
+			t.Fatalf("#%d: TrailingZeros(%d): expected %d, got %d", i, x, want, got)
+		}
+	}
+}
+
 func TestTrailingZeros256(t *testing.T) {
 	for i, tc := range []struct {
 		x Uint256
@@ -335,6 +763,148 @@ func TestExp256(t *testing.T) {
 	}
 }
 
+func TestExpCT256(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		x, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := Rand256(rng, max256.Rsh(uint(rand.Intn(256))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.BitLen() == 0 {
+			m = U256(1)
+		}
+		z := x.ExpCT(y, m)
+
+		var bz, bx, by, bm big.Int
+		setInt(&bx, x)
+		setInt(&by, y)
+		setInt(&bm, m)
+		bz.Exp(&bx, &by, &bm)
+
+		if cmpInt(&bz, z) != 0 {
+			t.Fatalf("#%d: %d^%d mod %d: expected %s, got %d",
+				i, x, y, m, bz.String(), z)
+		}
+	}
+}
+
+func TestExpCT256Edge(t *testing.T) {
+	for i, tc := range []struct {
+		x, y, m Uint256
+	}{
+		{U256(0), U256(0), U256(1)},
+		{U256(0), U256(0), U256(2)},
+		{U256(0), U256(0), U256(3)},
+		{U256(5), U256(0), U256(7)},
+		{U256(5), U256(0), U256(8)},
+		{max256, max256, max256},
+		{max256, max256, max256.Sub(U256(1))},
+		{max256, U256(1), U256(2)},
+		{U256(2), U256(255), max256},
+		{U256(2), U256(256), max256.Sub(U256(1))},
+	} {
+		var bz, bx, by, bm big.Int
+		setInt(&bx, tc.x)
+		setInt(&by, tc.y)
+		setInt(&bm, tc.m)
+		bz.Exp(&bx, &by, &bm)
+
+		if z := tc.x.ExpCT(tc.y, tc.m); cmpInt(&bz, z) != 0 {
+			t.Fatalf("#%d: ExpCT: expected %s, got %d", i, bz.String(), z)
+		}
+		if z := tc.x.Exp(tc.y, tc.m); cmpInt(&bz, z) != 0 {
+			t.Fatalf("#%d: Exp: expected %s, got %d", i, bz.String(), z)
+		}
+	}
+}
+
+func TestModInverse256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Vary the size of the modulus.
+		max := max256.Rsh(uint(rand.Intn(256)))
+		n, err := Rand256(rng, max)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n.u0 |= 1
+
+		var bz, bx, bn big.Int
+		setInt(&bx, x)
+		setInt(&bn, n)
+		ok := bz.ModInverse(&bx, &bn) != nil
+
+		z, v := x.ModInverseCT(n)
+		if (v == 1) != ok {
+			t.Fatalf("#%d: (%d)^-1 mod %d: expected %t, got %d", i, x, n, ok, v)
+		}
+		if ok && cmpInt(&bz, z) != 0 {
+			t.Fatalf("#%d: (%d)^-1 mod %d: expected %s, got %d",
+				i, x, n, bz.String(), z)
+		}
+	}
+}
+
+func TestModInverse256Edge(t *testing.T) {
+	for i, tc := range []struct {
+		x, n Uint256
+	}{
+		{U256(0), U256(1)},
+		{U256(1), U256(1)},
+		{max256, U256(1)},
+		{U256(1), U256(3)},
+		{U256(2), U256(3)},
+		{U256(3), U256(4)},
+		{U256(7), U256(1 << 10)},
+		{max256, max256.Sub(U256(1))},
+		{max256.Sub(U256(1)), max256},
+		{max256.Sub(U256(1)), U256(math.MaxUint64)},
+		{U256(7), max256},
+	} {
+		var bz, bx, bn big.Int
+		setInt(&bx, tc.x)
+		setInt(&bn, tc.n)
+		if bz.ModInverse(&bx, &bn) == nil {
+			t.Fatalf("#%d: bad test case", i)
+		}
+		z := tc.x.ModInverse(tc.n)
+		if cmpInt(&bz, z) != 0 {
+			t.Fatalf("#%d: (%d)^-1 mod %d: expected %s, got %d",
+				i, tc.x, tc.n, bz.String(), z)
+		}
+	}
+}
+
+func TestModInverse256Panic(t *testing.T) {
+	for i, tc := range []struct {
+		x, n Uint256
+	}{
+		{U256(0), U256(3)},
+		{U256(3), U256(9)},
+		{U256(2), U256(4)},
+		{U256(2), U256(0)},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("#%d: expected a panic", i)
+				}
+			}()
+			tc.x.ModInverse(tc.n)
+		}()
+	}
+}
+
 func exp(z, g, n, m *big.Int) *big.Int {
 	x1 := new(big.Int).Set(g)
 	x2 := new(big.Int).Mul(g, g)
@@ -375,6 +945,34 @@ func TestBits256(t *testing.T) {
 	}
 }
 
+func TestReverse256(t *testing.T) {
+	for i := 0; i < 10_000; i++ {
+		x, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if z := x.Reverse().Reverse(); z != x {
+			t.Fatalf("#%d: Reverse(Reverse(%d)) = %d", i, x, z)
+		}
+		if z := x.ReverseBytes().ReverseBytes(); z != x {
+			t.Fatalf("#%d: ReverseBytes(ReverseBytes(%d)) = %d", i, x, z)
+		}
+		r := x.Reverse()
+		for j := 0; j < 256; j++ {
+			if r.Bit(256-1-j) != x.Bit(j) {
+				t.Fatalf("#%d: Reverse(%d): bit %d not moved to bit %d", i, x, j, 256-1-j)
+			}
+		}
+		b := x.Bytes32()
+		rb := x.ReverseBytes().Bytes32()
+		for j := range b {
+			if rb[len(rb)-1-j] != b[j] {
+				t.Fatalf("#%d: ReverseBytes(%d): byte %d not moved to byte %d", i, x, j, len(b)-1-j)
+			}
+		}
+	}
+}
+
 var Sink256 Uint256
 
 func BenchmarkAdd256(b *testing.B) {
@@ -425,6 +1023,40 @@ func BenchmarkMul256(b *testing.B) {
 	}
 }
 
+func BenchmarkSqr256(b *testing.B) {
+	x, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("Sqr", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Sink256 = x.Sqr()
+		}
+	})
+	b.Run("Mul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Sink256 = x.Mul(x)
+		}
+	})
+}
+
+func BenchmarkSqrFull256(b *testing.B) {
+	x, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("SqrFull", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Sink512 = x.SqrFull()
+		}
+	})
+	b.Run("MulFull", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Sink512 = x.MulFull(x)
+		}
+	})
+}
+
 func BenchmarkLsh256(b *testing.B) {
 	x, err := Rand256(rng, max256)
 	if err != nil {
@@ -480,6 +1112,77 @@ func BenchmarkQuoRem256Small(b *testing.B) {
 	}
 }
 
+func BenchmarkQuoRemCT256(b *testing.B) {
+	x, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	y, err := Rand256(rng, max128)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink256, _ = x.QuoRemCT(y)
+	}
+}
+
+func BenchmarkExp256(b *testing.B) {
+	x, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	y, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	m, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	m.u0 |= 1
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink256 = x.Exp(y, m)
+	}
+}
+
+func BenchmarkExpCT256(b *testing.B) {
+	x, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	y, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	m, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	m.u0 |= 1
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink256 = x.ExpCT(y, m)
+	}
+}
+
+func BenchmarkModInverse256(b *testing.B) {
+	x, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	n, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	n.u0 |= 1
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink256, _ = x.ModInverseCT(n)
+	}
+}
+
 func BenchmarkAnd256(b *testing.B) {
 	x, err := Rand256(rng, max256)
 	if err != nil {
@@ -524,3 +1227,49 @@ func BenchmarkOr256(b *testing.B) {
 		Sink256 = x.Or(y)
 	}
 }
+
+func TestUint256MulFull(t *testing.T) {
+	for i := 0; i < 100_000; i++ {
+		x, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := Rand256(rng, max256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		z := x.MulFull(y)
+
+		var bz, bx, by big.Int
+		setInt(&bx, x)
+		setInt(&by, y)
+		bz.Mul(&bx, &by)
+
+		if cmpInt512(&bz, z) != 0 {
+			t.Fatalf("#%d: expected %s, got %d", i, bz.String(), z)
+		}
+		if z.Lo() != x.Mul(y) {
+			t.Fatalf("#%d: expected %d, got %d", i, x.Mul(y), z.Lo())
+		}
+		var hi big.Int
+		hi.Rsh(&bz, 256)
+		if cmpInt(&hi, z.Hi()) != 0 {
+			t.Fatalf("#%d: expected %s, got %d", i, hi.String(), z.Hi())
+		}
+	}
+}
+
+func BenchmarkUint256MulFull(b *testing.B) {
+	x, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	y, err := Rand256(rng, max256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sink512 = x.MulFull(y)
+	}
+}